@@ -1,3 +1,7 @@
+// Package container defines the interfaces shared by the data structures of this module.
+//
+// Containers that support range-over-func give their iterators a Seq suffix (AllSeq, BackwardSeq, KeysSeq,
+// ValuesSeq, RangeSeq), since All is the predicate of EnumerableWithKey and EnumerableWithIndex, and Keys and Values return slices.
 package container
 
 // Container is base interface that all data structures implement.
@@ -0,0 +1,206 @@
+// Package containertest provides conformance test suites for the interfaces of package container.
+package containertest
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/morganxf/algorithm/container"
+)
+
+// TestMap runs the conformance suite of container.Map against the maps returned by newMap.
+// newMap must return an empty map accepting int keys.
+func TestMap(t *testing.T, newMap func() container.Map) {
+	t.Run("PutGetRemove", func(t *testing.T) {
+		m := newMap()
+		if actualValue := m.Empty(); actualValue != true {
+			t.Errorf("Got %v expected %v", actualValue, true)
+		}
+		m.Put(5, "e")
+		m.Put(6, "f")
+		m.Put(7, "g")
+		m.Put(3, "c")
+		m.Put(4, "d")
+		m.Put(1, "x")
+		m.Put(2, "b")
+		m.Put(1, "a") //overwrite
+
+		if actualValue := m.Size(); actualValue != 7 {
+			t.Errorf("Got %v expected %v", actualValue, 7)
+		}
+		tests := [][]interface{}{
+			{0, nil, false},
+			{1, "a", true},
+			{4, "d", true},
+			{7, "g", true},
+			{8, nil, false},
+		}
+		for _, test := range tests {
+			if value, found := m.Get(test[0]); value != test[1] || found != test[2] {
+				t.Errorf("Got %v,%v expected %v,%v", value, found, test[1], test[2])
+			}
+		}
+
+		m.Remove(5)
+		m.Remove(5)
+		m.Remove(8)
+		if value, found := m.Get(5); value != nil || found {
+			t.Errorf("Got %v,%v expected %v,%v", value, found, nil, false)
+		}
+		if actualValue := m.Size(); actualValue != 6 {
+			t.Errorf("Got %v expected %v", actualValue, 6)
+		}
+		if actualValue := len(m.Keys()); actualValue != 6 {
+			t.Errorf("Got %v expected %v", actualValue, 6)
+		}
+
+		m.Clear()
+		if actualValue := m.Empty(); actualValue != true {
+			t.Errorf("Got %v expected %v", actualValue, true)
+		}
+		if actualValue := len(m.Values()); actualValue != 0 {
+			t.Errorf("Got %v expected %v", actualValue, 0)
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		m := newMap()
+		expected := make(map[int]int)
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 2000; i++ {
+			key := r.Intn(500)
+			if r.Intn(3) == 0 {
+				m.Remove(key)
+				delete(expected, key)
+			} else {
+				m.Put(key, i)
+				expected[key] = i
+			}
+		}
+		if actualValue := m.Size(); actualValue != len(expected) {
+			t.Errorf("Got %v expected %v", actualValue, len(expected))
+		}
+		for key := 0; key < 500; key++ {
+			value, found := m.Get(key)
+			if expectedValue, ok := expected[key]; found != ok || (ok && value != expectedValue) {
+				t.Errorf("Got %v,%v expected %v,%v", value, found, expectedValue, ok)
+			}
+		}
+	})
+}
+
+// TestSortedMap runs the conformance suites of container.Map and container.SortedMap against the maps
+// returned by newMap. newMap must return an empty map ordered by util.IntComparator.
+func TestSortedMap(t *testing.T, newMap func() container.SortedMap) {
+	TestMap(t, func() container.Map { return newMap() })
+
+	t.Run("Order", func(t *testing.T) {
+		m := newMap()
+		for _, key := range rand.New(rand.NewSource(1)).Perm(100) {
+			m.Put(key, fmt.Sprint(key))
+		}
+		keys, values := m.Keys(), m.Values()
+		for i := 0; i < 100; i++ {
+			if keys[i] != i || values[i] != fmt.Sprint(i) {
+				t.Errorf("Got %v,%v expected %v,%v", keys[i], values[i], i, fmt.Sprint(i))
+			}
+		}
+	})
+
+	t.Run("MinMax", func(t *testing.T) {
+		m := newMap()
+		if key, value, found := m.Min(); key != nil || value != nil || found {
+			t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, nil, nil, false)
+		}
+		if key, value, found := m.Max(); key != nil || value != nil || found {
+			t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, nil, nil, false)
+		}
+		m.Put(2, "b")
+		m.Put(3, "c")
+		m.Put(1, "a")
+		if key, value, found := m.Min(); key != 1 || value != "a" || !found {
+			t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, 1, "a", true)
+		}
+		if key, value, found := m.Max(); key != 3 || value != "c" || !found {
+			t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, 3, "c", true)
+		}
+	})
+
+	t.Run("FloorCeiling", func(t *testing.T) {
+		m := newMap()
+		if key, value, found := m.FloorEntry(0); key != nil || value != nil || found {
+			t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, nil, nil, false)
+		}
+		if key, value, found := m.CeilingEntry(0); key != nil || value != nil || found {
+			t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, nil, nil, false)
+		}
+		for key := 10; key <= 100; key += 10 {
+			m.Put(key, key*2)
+		}
+		tests := [][]interface{}{
+			// key, floor, floor found, ceiling, ceiling found
+			{5, nil, false, 10, true},
+			{10, 10, true, 10, true},
+			{11, 10, true, 20, true},
+			{55, 50, true, 60, true},
+			{99, 90, true, 100, true},
+			{100, 100, true, 100, true},
+			{101, 100, true, nil, false},
+		}
+		for _, test := range tests {
+			if key, value, found := m.FloorEntry(test[0]); key != test[1] || found != test[2] || (found && value != key.(int)*2) {
+				t.Errorf("Got %v,%v,%v expected %v,%v", key, value, found, test[1], test[2])
+			}
+			if key, value, found := m.CeilingEntry(test[0]); key != test[3] || found != test[4] || (found && value != key.(int)*2) {
+				t.Errorf("Got %v,%v,%v expected %v,%v", key, value, found, test[3], test[4])
+			}
+		}
+	})
+
+	t.Run("Iterator", func(t *testing.T) {
+		m := newMap()
+		it := m.EntryIterator()
+		if actualValue := it.Next(); actualValue != false {
+			t.Errorf("Got %v expected %v", actualValue, false)
+		}
+		keys := rand.New(rand.NewSource(2)).Perm(50)
+		for _, key := range keys {
+			m.Put(key, -key)
+		}
+		sort.Ints(keys)
+
+		it = m.EntryIterator()
+		count := 0
+		for it.Next() {
+			if key, value := it.Key(), it.Value(); key != keys[count] || value != -keys[count] {
+				t.Errorf("Got %v,%v expected %v,%v", key, value, keys[count], -keys[count])
+			}
+			count++
+		}
+		if count != len(keys) {
+			t.Errorf("Got %v expected %v", count, len(keys))
+		}
+		for it.Prev() {
+			count--
+			if key := it.Key(); key != keys[count] {
+				t.Errorf("Got %v expected %v", key, keys[count])
+			}
+		}
+		if count != 0 {
+			t.Errorf("Got %v expected %v", count, 0)
+		}
+
+		if actualValue := it.Last(); actualValue != true || it.Key() != keys[len(keys)-1] {
+			t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), true, keys[len(keys)-1])
+		}
+		if actualValue := it.First(); actualValue != true || it.Key() != keys[0] {
+			t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), true, keys[0])
+		}
+		it.End()
+		if actualValue := it.Prev(); actualValue != true || it.Key() != keys[len(keys)-1] {
+			t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), true, keys[len(keys)-1])
+		}
+	})
+}
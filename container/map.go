@@ -0,0 +1,35 @@
+package container
+
+// Map is the interface of containers holding key value pairs with unique keys.
+type Map interface {
+	Put(key interface{}, value interface{})
+	Get(key interface{}) (value interface{}, found bool)
+	Remove(key interface{})
+	Keys() []interface{}
+
+	Container
+}
+
+// SortedMap is a Map whose keys are kept in the order defined by a comparator.
+//
+// Keys() and Values() return the elements in key order.
+type SortedMap interface {
+	// Min returns the smallest key and its value, found is false if the map is empty.
+	Min() (key interface{}, value interface{}, found bool)
+
+	// Max returns the largest key and its value, found is false if the map is empty.
+	Max() (key interface{}, value interface{}, found bool)
+
+	// FloorEntry returns the largest key less than or equal to the given key and its value.
+	// found is false if there is no such key.
+	FloorEntry(key interface{}) (floorKey interface{}, floorValue interface{}, found bool)
+
+	// CeilingEntry returns the smallest key greater than or equal to the given key and its value.
+	// found is false if there is no such key.
+	CeilingEntry(key interface{}) (ceilingKey interface{}, ceilingValue interface{}, found bool)
+
+	// EntryIterator returns a stateful iterator over the entries in key order.
+	EntryIterator() ReverseIteratorWithKey
+
+	Map
+}
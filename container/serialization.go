@@ -1 +1,13 @@
 package container
+
+// JSONSerializer provides JSON serialization.
+type JSONSerializer interface {
+	// ToJSON outputs the JSON representation of the container's elements.
+	ToJSON() ([]byte, error)
+}
+
+// JSONDeserializer provides JSON deserialization.
+type JSONDeserializer interface {
+	// FromJSON replaces the container's elements with the ones in the JSON representation.
+	FromJSON([]byte) error
+}
@@ -1,5 +1,5 @@
 module github.com/morganxf/algorithm
 
-go 1.14
+go 1.23
 
 require github.com/emirpasic/gods v1.12.0
@@ -0,0 +1,99 @@
+package skiplist
+
+import "github.com/morganxf/algorithm/container"
+
+func assertIteratorImplementation() {
+	var _ container.ReverseIteratorWithKey = (*Iterator)(nil)
+}
+
+type Iterator struct {
+	list     *SkipList
+	node     *Node
+	position position
+}
+
+type position byte
+
+const (
+	begin, between, end position = 0, 1, 2
+)
+
+func (list *SkipList) Iterator() *Iterator {
+	return &Iterator{list: list, node: nil, position: begin}
+}
+
+func (it *Iterator) Next() bool {
+	switch it.position {
+	case begin:
+		it.node = it.list.Left()
+		it.position = between
+	case between:
+		it.node = it.node.Next()
+	}
+	if it.node == nil {
+		it.position = end
+		return false
+	}
+	return true
+}
+
+func (it *Iterator) Prev() bool {
+	switch it.position {
+	case end:
+		it.node = it.list.Right()
+		it.position = between
+	case between:
+		it.node = it.node.Prev()
+	}
+	if it.node == nil {
+		it.position = begin
+		return false
+	}
+	return true
+}
+
+// Seek moves the iterator to the first element whose key is greater than or equal to key in O(log n),
+// so that a range scan is a Seek followed by calls to Next. It returns false and moves past the end if there is none.
+func (it *Iterator) Seek(key interface{}) bool {
+	it.node, _ = it.list.Ceiling(key)
+	if it.node == nil {
+		it.position = end
+		return false
+	}
+	it.position = between
+	return true
+}
+
+func (it *Iterator) Key() interface{} {
+	if it.node == nil {
+		return nil
+	}
+	return it.node.Key
+}
+
+func (it *Iterator) Value() interface{} {
+	if it.node == nil {
+		return nil
+	}
+	return it.node.Value
+}
+
+func (it *Iterator) Begin() {
+	it.node = nil
+	it.position = begin
+}
+
+func (it *Iterator) End() {
+	it.node = nil
+	it.position = end
+}
+
+func (it *Iterator) First() bool {
+	it.Begin()
+	return it.Next()
+}
+
+func (it *Iterator) Last() bool {
+	it.End()
+	return it.Prev()
+}
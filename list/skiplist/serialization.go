@@ -0,0 +1,45 @@
+package skiplist
+
+import (
+	"encoding/json"
+
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/util"
+)
+
+func assertSerializationImplementation() {
+	var _ container.JSONSerializer = (*SkipList)(nil)
+	var _ container.JSONDeserializer = (*SkipList)(nil)
+	var _ json.Marshaler = (*SkipList)(nil)
+	var _ json.Unmarshaler = (*SkipList)(nil)
+}
+
+// ToJSON encodes the list as a JSON array of {key,value} pairs in key order.
+// The type of built-in keys is recorded so that FromJSON can restore it.
+func (list *SkipList) ToJSON() ([]byte, error) {
+	return util.MarshalEntries(list.Keys(), list.Values())
+}
+
+// FromJSON replaces the contents of the list with the output of ToJSON.
+// Keys of custom types are restored by list.KeyDecoder.
+func (list *SkipList) FromJSON(data []byte) error {
+	keys, values, err := util.UnmarshalEntries(data, list.KeyDecoder)
+	if err != nil {
+		return err
+	}
+	list.Clear()
+	for i, key := range keys {
+		list.Put(key, values[i])
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, it is equivalent to ToJSON.
+func (list *SkipList) MarshalJSON() ([]byte, error) {
+	return list.ToJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it is equivalent to FromJSON.
+func (list *SkipList) UnmarshalJSON(data []byte) error {
+	return list.FromJSON(data)
+}
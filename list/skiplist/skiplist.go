@@ -0,0 +1,306 @@
+package skiplist
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+const (
+	// DefaultMaxLevel is the max level used when Options.MaxLevel is zero, enough for 4^32 elements.
+	DefaultMaxLevel = 32
+	// DefaultProbability is the level probability used when Options.Probability is zero.
+	DefaultProbability = 0.25
+)
+
+// Options configures a SkipList. Zero fields take their defaults.
+type Options struct {
+	// MaxLevel limits the number of forward pointers of a node, in [1, 64].
+	MaxLevel int
+	// Probability is the chance that a node of level l also has level l+1, in (0, 1).
+	Probability float64
+	// Seed seeds the level generator. Lists built by the same operations with the same seed
+	// have the same structure, which keeps tests reproducible.
+	Seed int64
+}
+
+// SkipList is an ordered map. Lookups never modify the list, so any number of goroutines
+// may read it concurrently as long as no goroutine writes it.
+// The zero value with a Comparator is an empty list with the default options.
+type SkipList struct {
+	Comparator  util.Comparator
+	KeyDecoder  util.KeyDecoder // 可选, FromJSON用于还原自定义类型的key
+	head        *Node           // 哨兵, 不存放entry, 拥有maxLevel个forward指针, 零值在第一次写入时创建
+	tail        *Node
+	level       int // 当前最高的level
+	size        int
+	maxLevel    int
+	probability float64
+	rand        *rand.Rand
+}
+
+type Node struct {
+	Key   interface{}
+	Value interface{}
+	next  []*Node // next[i]为第i层的后继
+	prev  *Node   // 第0层的前驱, 用于反向迭代
+}
+
+func NewWith(comparator util.Comparator) *SkipList {
+	return NewWithOptions(comparator, Options{})
+}
+
+func NewWithIntComparator() *SkipList {
+	return NewWith(util.IntComparator)
+}
+
+func NewWithStringComparator() *SkipList {
+	return NewWith(util.StringComparator)
+}
+
+// NewWithOptions returns an empty skip list, it panics if the options are out of range.
+func NewWithOptions(comparator util.Comparator, options Options) *SkipList {
+	if options.MaxLevel == 0 {
+		options.MaxLevel = DefaultMaxLevel
+	}
+	if options.Probability == 0 {
+		options.Probability = DefaultProbability
+	}
+	if options.MaxLevel < 1 || options.MaxLevel > 64 {
+		panic("Invalid max level, should be in [1, 64]")
+	}
+	if options.Probability <= 0 || options.Probability >= 1 {
+		panic("Invalid probability, should be in (0, 1)")
+	}
+	list := &SkipList{
+		Comparator:  comparator,
+		maxLevel:    options.MaxLevel,
+		probability: options.Probability,
+		rand:        rand.New(rand.NewSource(options.Seed)),
+	}
+	list.init()
+	return list
+}
+
+func (list *SkipList) Put(key interface{}, value interface{}) {
+	list.init()
+	update := make([]*Node, list.maxLevel)
+	cur := list.search(key, update)
+	if next := cur.next[0]; next != nil && list.Comparator(next.Key, key) == 0 {
+		next.Key = key
+		next.Value = value
+		return
+	}
+	level := list.randomLevel()
+	if level > list.level {
+		for i := list.level; i < level; i++ {
+			update[i] = list.head
+		}
+		list.level = level
+	}
+	node := &Node{Key: key, Value: value, next: make([]*Node, level)}
+	// 在update记录的每一层node之后插入
+	for i := 0; i < level; i++ {
+		node.next[i] = update[i].next[i]
+		update[i].next[i] = node
+	}
+	if update[0] != list.head {
+		node.prev = update[0]
+	}
+	if node.next[0] != nil {
+		node.next[0].prev = node
+	} else {
+		list.tail = node
+	}
+	list.size++
+}
+
+func (list *SkipList) Get(key interface{}) (interface{}, bool) {
+	if list.head == nil {
+		return nil, false
+	}
+	cur := list.search(key, nil).next[0]
+	if cur != nil && list.Comparator(cur.Key, key) == 0 {
+		return cur.Value, true
+	}
+	return nil, false
+}
+
+func (list *SkipList) Remove(key interface{}) {
+	if list.head == nil {
+		return
+	}
+	update := make([]*Node, list.maxLevel)
+	node := list.search(key, update).next[0]
+	if node == nil || list.Comparator(node.Key, key) != 0 {
+		return
+	}
+	for i := 0; i < len(node.next); i++ {
+		update[i].next[i] = node.next[i]
+	}
+	if node.next[0] != nil {
+		node.next[0].prev = node.prev
+	} else {
+		list.tail = node.prev
+	}
+	// 降低没有node的level
+	for list.level > 1 && list.head.next[list.level-1] == nil {
+		list.level--
+	}
+	list.size--
+}
+
+// Left returns the node with the smallest key, nil if the list is empty.
+func (list *SkipList) Left() *Node {
+	if list.head == nil {
+		return nil
+	}
+	return list.head.next[0]
+}
+
+// Right returns the node with the largest key, nil if the list is empty.
+func (list *SkipList) Right() *Node {
+	return list.tail
+}
+
+// Floor returns the node with the largest key less than or equal to the given key.
+func (list *SkipList) Floor(key interface{}) (*Node, bool) {
+	if list.head == nil {
+		return nil, false
+	}
+	cur := list.head
+	for i := list.level - 1; i >= 0; i-- {
+		for cur.next[i] != nil && list.Comparator(cur.next[i].Key, key) <= 0 {
+			cur = cur.next[i]
+		}
+	}
+	if cur == list.head {
+		return nil, false
+	}
+	return cur, true
+}
+
+// Ceiling returns the node with the smallest key greater than or equal to the given key.
+func (list *SkipList) Ceiling(key interface{}) (*Node, bool) {
+	if list.head == nil {
+		return nil, false
+	}
+	ceiling := list.search(key, nil).next[0]
+	return ceiling, ceiling != nil
+}
+
+func (list *SkipList) Empty() bool {
+	return list.size == 0
+}
+
+func (list *SkipList) Size() int {
+	return list.size
+}
+
+// Level returns the current number of levels of the list.
+func (list *SkipList) Level() int {
+	return list.level
+}
+
+func (list *SkipList) Keys() []interface{} {
+	keys := make([]interface{}, 0, list.size)
+	for node := list.Left(); node != nil; node = node.Next() {
+		keys = append(keys, node.Key)
+	}
+	return keys
+}
+
+func (list *SkipList) Values() []interface{} {
+	values := make([]interface{}, 0, list.size)
+	for node := list.Left(); node != nil; node = node.Next() {
+		values = append(values, node.Value)
+	}
+	return values
+}
+
+// Clear removes all the entries, the state of the level generator is kept.
+func (list *SkipList) Clear() {
+	list.head = nil
+	list.tail = nil
+	list.size = 0
+	list.init()
+}
+
+// String outputs the keys of every level, from the top level down.
+func (list *SkipList) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("SkipList\n")
+	if list.Empty() {
+		return buffer.String()
+	}
+	for i := list.level - 1; i >= 0; i-- {
+		buffer.WriteString(fmt.Sprintf("L%d:", i))
+		for node := list.head.next[i]; node != nil; node = node.next[i] {
+			buffer.WriteString(fmt.Sprintf(" %v", node.Key))
+		}
+		buffer.WriteString("\n")
+	}
+	return buffer.String()
+}
+
+// init为零值SkipList设置默认参数并创建哨兵, 只在写操作中调用, 读操作不修改list
+func (list *SkipList) init() {
+	if list.head != nil {
+		return
+	}
+	if list.maxLevel == 0 {
+		list.maxLevel = DefaultMaxLevel
+	}
+	if list.probability == 0 {
+		list.probability = DefaultProbability
+	}
+	if list.rand == nil {
+		list.rand = rand.New(rand.NewSource(0))
+	}
+	list.head = &Node{next: make([]*Node, list.maxLevel)}
+	list.level = 1
+}
+
+// search返回每一层中key小于给定key的最后一个node, update非nil时记录每一层的该node
+func (list *SkipList) search(key interface{}, update []*Node) *Node {
+	cur := list.head
+	for i := list.level - 1; i >= 0; i-- {
+		for cur.next[i] != nil && list.Comparator(cur.next[i].Key, key) < 0 {
+			cur = cur.next[i]
+		}
+		if update != nil {
+			update[i] = cur
+		}
+	}
+	return cur
+}
+
+// randomLevel以probability的概率逐层增加level, level服从几何分布
+func (list *SkipList) randomLevel() int {
+	level := 1
+	for level < list.maxLevel && list.rand.Float64() < list.probability {
+		level++
+	}
+	return level
+}
+
+// Next returns the node with the next larger key, nil if n is the last one.
+func (n *Node) Next() *Node {
+	return n.next[0]
+}
+
+// Prev returns the node with the next smaller key, nil if n is the first one.
+func (n *Node) Prev() *Node {
+	return n.prev
+}
+
+// Level returns the number of forward pointers of the node.
+func (n *Node) Level() int {
+	return len(n.next)
+}
+
+func (n *Node) String() string {
+	return fmt.Sprintf("%v", n.Key)
+}
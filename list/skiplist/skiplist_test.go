@@ -0,0 +1,449 @@
+package skiplist
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/container/containertest"
+	"github.com/morganxf/algorithm/util"
+)
+
+func TestSkipListPutGetRemove(t *testing.T) {
+	list := NewWithIntComparator()
+	list.Put(5, "e")
+	list.Put(6, "f")
+	list.Put(7, "g")
+	list.Put(3, "c")
+	list.Put(4, "d")
+	list.Put(1, "x")
+	list.Put(2, "b")
+	list.Put(1, "a") //overwrite
+
+	if actualValue := list.Size(); actualValue != 7 {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+	if actualValue, expectedValue := fmt.Sprint(list.Keys(), list.Values()), "[1 2 3 4 5 6 7] [a b c d e f g]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tests := [][]interface{}{
+		{0, nil, false},
+		{1, "a", true},
+		{4, "d", true},
+		{7, "g", true},
+		{8, nil, false},
+	}
+	for _, test := range tests {
+		if value, found := list.Get(test[0]); value != test[1] || found != test[2] {
+			t.Errorf("Got %v,%v expected %v,%v", value, found, test[1], test[2])
+		}
+	}
+
+	list.Remove(1)
+	list.Remove(7)
+	list.Remove(4)
+	list.Remove(8)
+	if actualValue, expectedValue := fmt.Sprint(list.Keys()), "[2 3 5 6]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if left, right := list.Left(), list.Right(); left.Key != 2 || right.Key != 6 {
+		t.Errorf("Got %v,%v expected %v,%v", left, right, 2, 6)
+	}
+
+	list.Clear()
+	if actualValue := list.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if left, right := list.Left(), list.Right(); left != nil || right != nil {
+		t.Errorf("Got %v,%v expected %v,%v", left, right, nil, nil)
+	}
+}
+
+func TestSkipListZeroValue(t *testing.T) {
+	list := &SkipList{Comparator: util.IntComparator}
+	if value, found := list.Get(1); value != nil || found {
+		t.Errorf("Got %v,%v expected %v,%v", value, found, nil, false)
+	}
+	if node, found := list.Floor(1); node != nil || found {
+		t.Errorf("Got %v,%v expected %v,%v", node, found, nil, false)
+	}
+	if node, found := list.Ceiling(1); node != nil || found {
+		t.Errorf("Got %v,%v expected %v,%v", node, found, nil, false)
+	}
+	list.Remove(1)
+	if actualValue := list.Left(); actualValue != nil {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	list.Put(2, "b")
+	list.Put(1, "a")
+	if actualValue, expectedValue := fmt.Sprint(list.Keys(), list.Values()), "[1 2] [a b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	list.Clear()
+	list.Put(3, "c")
+	if value, found := list.Get(3); value != "c" || !found {
+		t.Errorf("Got %v,%v expected %v,%v", value, found, "c", true)
+	}
+}
+
+func TestSkipListPutEqualKey(t *testing.T) {
+	type key struct {
+		id      int
+		version int
+	}
+	list := NewWith(func(a, b interface{}) int {
+		return util.IntComparator(a.(key).id, b.(key).id)
+	})
+	list.Put(key{1, 1}, "a")
+	list.Put(key{1, 2}, "b")
+	if actualValue := list.Size(); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if node := list.Left(); node.Key != (key{1, 2}) || node.Value != "b" {
+		t.Errorf("Got %v,%v expected %v,%v", node.Key, node.Value, key{1, 2}, "b")
+	}
+}
+
+func TestSkipListCeilingAndFloor(t *testing.T) {
+	list := NewWithIntComparator()
+	if node, found := list.Floor(0); node != nil || found {
+		t.Errorf("Got %v expected %v", node, "<nil>")
+	}
+	if node, found := list.Ceiling(0); node != nil || found {
+		t.Errorf("Got %v expected %v", node, "<nil>")
+	}
+
+	for key := 2; key <= 10; key += 2 {
+		list.Put(key, key)
+	}
+	tests := [][]interface{}{
+		{1, nil, 2},
+		{2, 2, 2},
+		{5, 4, 6},
+		{10, 10, 10},
+		{11, 10, nil},
+	}
+	for _, test := range tests {
+		if node, found := list.Floor(test[0]); (found && node.Key != test[1]) || found != (test[1] != nil) {
+			t.Errorf("Got %v expected %v", node, test[1])
+		}
+		if node, found := list.Ceiling(test[0]); (found && node.Key != test[2]) || found != (test[2] != nil) {
+			t.Errorf("Got %v expected %v", node, test[2])
+		}
+	}
+}
+
+func TestSkipListIteratorSeek(t *testing.T) {
+	list := NewWithIntComparator()
+	for key := 10; key > 0; key-- {
+		list.Put(key, key*key)
+	}
+
+	// 范围扫描[4, 7]
+	keys := []interface{}{}
+	it := list.Iterator()
+	for ok := it.Seek(4); ok && it.Key().(int) <= 7; ok = it.Next() {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[4 5 6 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue := it.Seek(11); actualValue != false || it.Key() != nil {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), false, nil)
+	}
+	if actualValue := it.Prev(); actualValue != true || it.Key() != 10 || it.Value() != 100 {
+		t.Errorf("Got %v,%v,%v expected %v,%v,%v", actualValue, it.Key(), it.Value(), true, 10, 100)
+	}
+
+	it.Seek(2)
+	if actualValue := it.Prev(); actualValue != true || it.Key() != 1 {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), true, 1)
+	}
+	if actualValue := it.Prev(); actualValue != false || it.Key() != nil {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), false, nil)
+	}
+	if actualValue := it.Next(); actualValue != true || it.Key() != 1 {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), true, 1)
+	}
+}
+
+func TestSkipListSeed(t *testing.T) {
+	build := func(seed int64) *SkipList {
+		list := NewWithOptions(func(a, b interface{}) int { return a.(int) - b.(int) }, Options{MaxLevel: 8, Probability: 0.5, Seed: seed})
+		for key := 0; key < 64; key++ {
+			list.Put(key, nil)
+		}
+		return list
+	}
+	if first, second := build(42).String(), build(42).String(); first != second {
+		t.Errorf("Got %v expected %v", first, second)
+	}
+	if first, second := build(42).String(), build(43).String(); first == second {
+		t.Errorf("Got the same structure for different seeds %v", first)
+	}
+	if actualValue := build(42).Level(); actualValue > 8 {
+		t.Errorf("Got %v expected at most %v", actualValue, 8)
+	}
+}
+
+func TestSkipListInvalidOptions(t *testing.T) {
+	tests := []Options{
+		{MaxLevel: -1},
+		{MaxLevel: 65},
+		{Probability: 1},
+		{Probability: -0.5},
+	}
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Got no panic for %+v", test)
+				}
+			}()
+			NewWithOptions(nil, test)
+		}()
+	}
+}
+
+func TestSkipListString(t *testing.T) {
+	list := NewWithOptions(func(a, b interface{}) int { return a.(int) - b.(int) }, Options{MaxLevel: 1})
+	if actualValue, expectedValue := list.String(), "SkipList\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	list.Put(2, nil)
+	list.Put(1, nil)
+	if actualValue, expectedValue := list.String(), "SkipList\nL0: 1 2\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSkipListRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	list := NewWithOptions(func(a, b interface{}) int { return a.(int) - b.(int) }, Options{Seed: 1})
+	expected := make(map[int]int)
+	for i := 0; i < 5000; i++ {
+		key := r.Intn(1000)
+		if r.Intn(2) == 0 {
+			list.Put(key, i)
+			expected[key] = i
+		} else {
+			list.Remove(key)
+			delete(expected, key)
+		}
+		if i%100 == 0 {
+			assertValidSkipList(t, list)
+		}
+	}
+	assertValidSkipList(t, list)
+	if actualValue := list.Size(); actualValue != len(expected) {
+		t.Errorf("Got %v expected %v", actualValue, len(expected))
+	}
+	for key, value := range expected {
+		if actualValue, found := list.Get(key); actualValue != value || !found {
+			t.Errorf("Got %v,%v expected %v,%v", actualValue, found, value, true)
+		}
+	}
+}
+
+// assertValidSkipList检查每一层都有序且是第0层的子序列, 以及prev指针、tail和level
+func assertValidSkipList(t *testing.T, list *SkipList) {
+	for i := 0; i < list.maxLevel; i++ {
+		if i >= list.level && list.head.next[i] != nil {
+			t.Errorf("Got node %v above level %v", list.head.next[i], list.level)
+		}
+		for node := list.head.next[i]; node != nil && node.next[i] != nil; node = node.next[i] {
+			if list.Comparator(node.Key, node.next[i].Key) >= 0 {
+				t.Errorf("Got %v before %v on level %v", node, node.next[i], i)
+			}
+		}
+	}
+	if list.level > 1 && list.head.next[list.level-1] == nil {
+		t.Errorf("Got empty top level %v", list.level)
+	}
+	var prev *Node
+	count := 0
+	for node := list.head.next[0]; node != nil; node = node.next[0] {
+		if node.prev != prev {
+			t.Errorf("Got prev %v expected %v", node.prev, prev)
+		}
+		prev = node
+		count++
+	}
+	if list.tail != prev {
+		t.Errorf("Got tail %v expected %v", list.tail, prev)
+	}
+	if count != list.Size() {
+		t.Errorf("Got %v expected %v", count, list.Size())
+	}
+}
+
+func TestSkipListSortedMap(t *testing.T) {
+	containertest.TestSortedMap(t, func() container.SortedMap { return NewWithIntComparator() })
+}
+
+func TestSkipListSerialization(t *testing.T) {
+	list := NewWithStringComparator()
+	list.Put("c", 3)
+	list.Put("a", 1)
+	list.Put("b", 2)
+
+	json, err := list.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	restored := NewWithStringComparator()
+	if err := restored.FromJSON(json); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(restored.Keys(), restored.Values()), "[a b c] [1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func benchmarkGet(b *testing.B, list *SkipList, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			list.Get(n)
+		}
+	}
+}
+
+func benchmarkPut(b *testing.B, list *SkipList, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			list.Put(n, struct{}{})
+		}
+	}
+}
+
+func benchmarkRemove(b *testing.B, list *SkipList, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			list.Remove(n)
+		}
+	}
+}
+
+func BenchmarkSkipListGet100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	list := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		list.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, list, size)
+}
+
+func BenchmarkSkipListGet1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	list := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		list.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, list, size)
+}
+
+func BenchmarkSkipListGet10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	list := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		list.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, list, size)
+}
+
+func BenchmarkSkipListGet100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	list := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		list.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, list, size)
+}
+
+func BenchmarkSkipListPut100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	list := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPut(b, list, size)
+}
+
+func BenchmarkSkipListPut1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	list := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPut(b, list, size)
+}
+
+func BenchmarkSkipListPut10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	list := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPut(b, list, size)
+}
+
+func BenchmarkSkipListPut100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	list := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPut(b, list, size)
+}
+
+func BenchmarkSkipListRemove100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	list := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		list.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkRemove(b, list, size)
+}
+
+func BenchmarkSkipListRemove1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	list := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		list.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkRemove(b, list, size)
+}
+
+func BenchmarkSkipListRemove10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	list := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		list.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkRemove(b, list, size)
+}
+
+func BenchmarkSkipListRemove100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	list := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		list.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkRemove(b, list, size)
+}
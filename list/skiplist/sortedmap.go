@@ -0,0 +1,36 @@
+package skiplist
+
+import "github.com/morganxf/algorithm/container"
+
+func assertSortedMapImplementation() {
+	var _ container.SortedMap = (*SkipList)(nil)
+}
+
+func (list *SkipList) Min() (key interface{}, value interface{}, found bool) {
+	return entry(list.Left())
+}
+
+func (list *SkipList) Max() (key interface{}, value interface{}, found bool) {
+	return entry(list.Right())
+}
+
+func (list *SkipList) FloorEntry(key interface{}) (floorKey interface{}, floorValue interface{}, found bool) {
+	node, _ := list.Floor(key)
+	return entry(node)
+}
+
+func (list *SkipList) CeilingEntry(key interface{}) (ceilingKey interface{}, ceilingValue interface{}, found bool) {
+	node, _ := list.Ceiling(key)
+	return entry(node)
+}
+
+func (list *SkipList) EntryIterator() container.ReverseIteratorWithKey {
+	return list.Iterator()
+}
+
+func entry(node *Node) (key interface{}, value interface{}, found bool) {
+	if node == nil {
+		return nil, nil, false
+	}
+	return node.Key, node.Value, true
+}
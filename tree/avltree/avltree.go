@@ -9,7 +9,9 @@ import (
 type Tree struct {
 	Root       *Node
 	Comparator util.Comparator
+	KeyDecoder util.KeyDecoder // 可选, FromJSON用于还原自定义类型的key
 	size       int
+	augment    func(n *Node)
 }
 
 type Node struct {
@@ -18,12 +20,20 @@ type Node struct {
 	Parent   *Node
 	Children [2]*Node // Left - Right
 	balance  int8
+	size     int // 以该节点为根的子树的节点数目
 }
 
 func NewWith(comparator util.Comparator) *Tree {
 	return &Tree{Comparator: comparator}
 }
 
+// NewWithAugment returns an empty tree that calls augment on every node whose subtree changed, after its children,
+// wherever the tree updates the subtree sizes used by Select and Rank, rotations included.
+// It lets a tree built on Tree keep other per-subtree data, such as the largest End of an interval tree.
+func NewWithAugment(comparator util.Comparator, augment func(n *Node)) *Tree {
+	return &Tree{Comparator: comparator, augment: augment}
+}
+
 func NewWithIntComparator() *Tree {
 	return &Tree{Comparator: util.IntComparator}
 }
@@ -96,51 +106,43 @@ func (t *Tree) Clear() {
 }
 
 func (t *Tree) Floor(key interface{}) (*Node, bool) {
-	var floor *Node
-	var found = false
-	cur := t.Root
-	for cur != nil {
-		cmp := t.Comparator(key, cur.Key)
-		switch {
-		case cmp < 0:
-			cur = cur.Children[0]
-		case cmp > 0:
-			// floor是比key小的node
-			floor, found = cur, true
-			// 继续 是为了找到最大的比key小的node
-			cur = cur.Children[1]
-		case cmp == 0:
-			return cur, true
-		}
-	}
-	if found {
-		return floor, found
-	}
-	return nil, false
+	floor := t.floor(key, false)
+	return floor, floor != nil
 }
 
 func (t *Tree) Ceiling(key interface{}) (*Node, bool) {
-	var ceiling *Node
-	var found = false
+	ceiling := t.ceiling(key, false)
+	return ceiling, ceiling != nil
+}
+
+// Select returns the node holding the index-th smallest key (0-based) in O(log n).
+func (t *Tree) Select(index int) (*Node, bool) {
+	if index < 0 || index >= t.size {
+		return nil, false
+	}
 	cur := t.Root
 	for cur != nil {
-		cmp := t.Comparator(key, cur.Key)
+		leftSize := cur.Children[0].Size()
 		switch {
-		case cmp < 0:
-			ceiling, found = cur, true
+		case index < leftSize:
 			cur = cur.Children[0]
-		case cmp > 0:
+		case index > leftSize:
+			// 跳过左子树和当前节点
+			index -= leftSize + 1
 			cur = cur.Children[1]
-		case cmp == 0:
+		default:
 			return cur, true
 		}
 	}
-	if found {
-		return ceiling, true
-	}
 	return nil, false
 }
 
+// Rank returns the number of keys in the tree strictly less than key in O(log n).
+// If key is in the tree, this is its 0-based index in sorted order.
+func (t *Tree) Rank(key interface{}) int {
+	return t.rank(key, false)
+}
+
 func (t *Tree) String() string {
 	str := "Tree\n"
 	if !t.Empty() {
@@ -155,6 +157,7 @@ func (t *Tree) put(key interface{}, value interface{}, parent *Node, target **No
 	if cur == nil {
 		t.size++
 		*target = &Node{Key: key, Value: value, Parent: parent}
+		t.resize(*target)
 		return true
 	}
 
@@ -162,6 +165,7 @@ func (t *Tree) put(key interface{}, value interface{}, parent *Node, target **No
 	if cmp == 0 {
 		cur.Key = key
 		cur.Value = value
+		t.resize(cur)
 		return false
 	}
 
@@ -172,10 +176,11 @@ func (t *Tree) put(key interface{}, value interface{}, parent *Node, target **No
 		newTarget = &cur.Children[1]
 	}
 	imbalanced := t.put(key, value, cur, newTarget)
+	t.resize(cur)
 	if imbalanced {
 		// *target == newTarget.Parent
 		// 增加node可能导致祖先的不平衡，重新平衡最小不平衡数
-		return putRebalance(int8(cmp), target)
+		return t.putRebalance(int8(cmp), target)
 	}
 	return false
 }
@@ -200,7 +205,13 @@ func (t *Tree) remove(key interface{}, target **Node) bool {
 			return true
 		}
 		// 使用右子树的最小node值替换当前node值
-		return removeMin(&cur.Children[1], &cur.Key, &cur.Value)
+		fix := t.removeMin(&cur.Children[1], &cur.Key, &cur.Value)
+		t.resize(cur)
+		if fix {
+			// 右子树高度降低
+			return t.removeFix(-1, target)
+		}
+		return false
 	}
 
 	var newTarget **Node
@@ -210,12 +221,77 @@ func (t *Tree) remove(key interface{}, target **Node) bool {
 		newTarget = &cur.Children[1]
 	}
 	fix := t.remove(key, newTarget)
+	t.resize(cur)
 	if fix {
-		return removeFix(int8(-cmp), target)
+		return t.removeFix(int8(-cmp), target)
 	}
 	return false
 }
 
+// floor返回不大于key的最大node; strict为true时返回小于key的最大node
+func (t *Tree) floor(key interface{}, strict bool) *Node {
+	var floor *Node
+	cur := t.Root
+	for cur != nil {
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp < 0 || (cmp == 0 && strict):
+			cur = cur.Children[0]
+		case cmp > 0:
+			// floor是比key小的node
+			floor = cur
+			// 继续 是为了找到最大的比key小的node
+			cur = cur.Children[1]
+		case cmp == 0:
+			return cur
+		}
+	}
+	return floor
+}
+
+// ceiling返回不小于key的最小node; strict为true时返回大于key的最小node
+func (t *Tree) ceiling(key interface{}, strict bool) *Node {
+	var ceiling *Node
+	cur := t.Root
+	for cur != nil {
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp < 0:
+			ceiling = cur
+			cur = cur.Children[0]
+		case cmp > 0 || (cmp == 0 && strict):
+			cur = cur.Children[1]
+		case cmp == 0:
+			return cur
+		}
+	}
+	return ceiling
+}
+
+// rank返回小于key的node数目; inclusive为true时返回小于等于key的node数目
+func (t *Tree) rank(key interface{}, inclusive bool) int {
+	rank := 0
+	cur := t.Root
+	for cur != nil {
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp < 0:
+			cur = cur.Children[0]
+		case cmp > 0:
+			// 左子树和当前节点都比key小
+			rank += cur.Children[0].Size() + 1
+			cur = cur.Children[1]
+		default:
+			rank += cur.Children[0].Size()
+			if inclusive {
+				rank++
+			}
+			return rank
+		}
+	}
+	return rank
+}
+
 func (t *Tree) bottom(d int) *Node {
 	if t.Root == nil {
 		return nil
@@ -226,7 +302,7 @@ func (t *Tree) bottom(d int) *Node {
 	return cur
 }
 
-func removeMin(target **Node, minKey *interface{}, minValue *interface{}) bool {
+func (t *Tree) removeMin(target **Node, minKey *interface{}, minValue *interface{}) bool {
 	cur := *target
 	// 找到本子树中最小的node
 	if cur.Children[0] == nil {
@@ -240,10 +316,16 @@ func removeMin(target **Node, minKey *interface{}, minValue *interface{}) bool {
 		*target = cur.Children[1]
 		return true
 	}
-	return removeMin(&cur.Children[0], minKey, minValue)
+	fix := t.removeMin(&cur.Children[0], minKey, minValue)
+	t.resize(cur)
+	if fix {
+		// 左子树高度降低
+		return t.removeFix(1, target)
+	}
+	return false
 }
 
-func putRebalance(c int8, root **Node) bool {
+func (t *Tree) putRebalance(c int8, root **Node) bool {
 	cur := *root
 	if cur.balance == 0 {
 		cur.balance = c
@@ -254,15 +336,15 @@ func putRebalance(c int8, root **Node) bool {
 		return false
 	}
 	if cur.Children[(c+1)/2].balance == c {
-		cur = singleRotate(c, cur)
+		cur = t.singleRotate(c, cur)
 	} else {
-		cur = doubleRotate(c, cur)
+		cur = t.doubleRotate(c, cur)
 	}
 	*root = cur
 	return false
 }
 
-func removeFix(c int8, root **Node) bool {
+func (t *Tree) removeFix(c int8, root **Node) bool {
 	cur := *root
 	if cur.balance == 0 {
 		cur.balance = c
@@ -276,36 +358,36 @@ func removeFix(c int8, root **Node) bool {
 
 	d := (c + 1) / 2
 	if cur.Children[d].balance == 0 {
-		cur = rotate(c, cur)
+		cur = t.rotate(c, cur)
 		cur.balance = -c
 		*root = cur
 		return false
 	}
 
 	if cur.Children[d].balance == c {
-		cur = singleRotate(c, cur)
+		cur = t.singleRotate(c, cur)
 	} else {
-		cur = doubleRotate(c, cur)
+		cur = t.doubleRotate(c, cur)
 	}
 	*root = cur
 	return true
 }
 
-func singleRotate(c int8, root *Node) *Node {
+func (t *Tree) singleRotate(c int8, root *Node) *Node {
 	root.balance = 0
 	// 旋转 得到新的平衡树的根
-	root = rotate(c, root)
+	root = t.rotate(c, root)
 	root.balance = 0
 	return root
 }
 
-func doubleRotate(c int8, root *Node) *Node {
+func (t *Tree) doubleRotate(c int8, root *Node) *Node {
 	d := (c + 1) / 2
 	child := root.Children[d]
 	// 第一次rotate
-	root.Children[d] = rotate(-c, root.Children[d])
+	root.Children[d] = t.rotate(-c, root.Children[d])
 	// 第二次rotate
-	newRoot := rotate(c, root)
+	newRoot := t.rotate(c, root)
 	switch {
 	case newRoot.balance == c:
 		root.balance = -c
@@ -324,7 +406,7 @@ func doubleRotate(c int8, root *Node) *Node {
 // 旋转
 // d == 0, LL, c == -1
 // d == 1, RR, c == +1
-func rotate(c int8, root *Node) *Node {
+func (t *Tree) rotate(c int8, root *Node) *Node {
 	d := (c + 1) / 2
 	// 旋转后 新的根节点
 	newRoot := root.Children[d]
@@ -337,6 +419,9 @@ func rotate(c int8, root *Node) *Node {
 	newRoot.Children[d^1] = root
 	newRoot.Parent = root.Parent
 	root.Parent = newRoot
+	// 先更新孩子 再更新新的根
+	t.resize(root)
+	t.resize(newRoot)
 	return newRoot
 }
 
@@ -356,10 +441,26 @@ func (n *Node) Prev() *Node {
 	return n.walk(0)
 }
 
+// Size returns the number of nodes in the subtree rooted at n.
+func (n *Node) Size() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
 func (n *Node) String() string {
 	return fmt.Sprintf("%v", n.Key)
 }
 
+// 根据孩子重新计算子树节点数目, 再由augment更新附加信息
+func (t *Tree) resize(n *Node) {
+	n.size = 1 + n.Children[0].Size() + n.Children[1].Size()
+	if t.augment != nil {
+		t.augment(n)
+	}
+}
+
 // 如果d==1, 则代表寻找第一个比之大的节点。为当前节点右子孩子中最小的节点或者祖先节点的第一个比之大的右孩子
 // 如果d==0, 则代表寻找第一个比之小的节点。为当前节点左子孩子中最大的节点或者祖先节点的第一个比之小的左孩子
 func (n *Node) walk(d int) *Node {
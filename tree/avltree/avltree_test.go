@@ -4,8 +4,14 @@
 package avltree
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/rand"
 	"testing"
+
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/container/containertest"
+	"github.com/morganxf/algorithm/util"
 )
 
 func TestAVLTreePut(t *testing.T) {
@@ -588,6 +594,511 @@ func TestAVLTreeSerialization(t *testing.T) {
 	assert()
 }
 
+func TestAVLTreeSelectAndRank(t *testing.T) {
+	tree := NewWithIntComparator()
+
+	if node, found := tree.Select(0); node != nil || found {
+		t.Errorf("Got %v expected %v", node, "<nil>")
+	}
+	if actualValue := tree.Rank(1); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+
+	for _, key := range []int{13, 8, 17, 1, 11, 15, 25, 6, 22, 27} {
+		tree.Put(key, key)
+	}
+	keys := tree.Keys()
+	for i, key := range keys {
+		if node, found := tree.Select(i); node.Key != key || !found {
+			t.Errorf("Got %v expected %v", node.Key, key)
+		}
+		if actualValue := tree.Rank(key); actualValue != i {
+			t.Errorf("Got %v expected %v", actualValue, i)
+		}
+	}
+	if node, found := tree.Select(-1); node != nil || found {
+		t.Errorf("Got %v expected %v", node, "<nil>")
+	}
+	if node, found := tree.Select(10); node != nil || found {
+		t.Errorf("Got %v expected %v", node, "<nil>")
+	}
+
+	tests := [][]interface{}{
+		{0, 0},
+		{7, 2},
+		{12, 4},
+		{26, 9},
+		{30, 10},
+	}
+	for _, test := range tests {
+		if actualValue := tree.Rank(test[0]); actualValue != test[1] {
+			t.Errorf("Got %v expected %v", actualValue, test[1])
+		}
+	}
+
+	tree.Remove(13)
+	tree.Remove(1)
+	if node, found := tree.Select(0); node.Key != 6 || !found {
+		t.Errorf("Got %v expected %v", node.Key, 6)
+	}
+	if node, found := tree.Select(4); node.Key != 17 || !found {
+		t.Errorf("Got %v expected %v", node.Key, 17)
+	}
+	if actualValue := tree.Rank(17); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+}
+
+func TestAVLTreeSelectAndRankRandom(t *testing.T) {
+	tree := NewWithIntComparator()
+	r := rand.New(rand.NewSource(3))
+	present := make(map[int]bool)
+	for i := 0; i < 2000; i++ {
+		key := r.Intn(500)
+		if r.Intn(3) == 0 {
+			tree.Remove(key)
+			delete(present, key)
+		} else {
+			tree.Put(key, key)
+			present[key] = true
+		}
+		if i%100 == 0 {
+			assertValidAVLTree(t, tree)
+		}
+	}
+	assertValidAVLTree(t, tree)
+	if actualValue, expectedValue := tree.Size(), len(present); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i, key := range tree.Keys() {
+		if node, _ := tree.Select(i); node.Key != key {
+			t.Errorf("Got %v expected %v", node.Key, key)
+		}
+		if actualValue := tree.Rank(key); actualValue != i {
+			t.Errorf("Got %v expected %v", actualValue, i)
+		}
+	}
+}
+
+func TestAVLTreeIteratorAt(t *testing.T) {
+	tree := NewWithIntComparator()
+	tree.Put(3, "c")
+	tree.Put(1, "a")
+	tree.Put(2, "b")
+	tree.Put(4, "d")
+
+	it := tree.IteratorAt(1)
+	if key, value := it.Key(), it.Value(); key != 2 || value != "b" {
+		t.Errorf("Got %v,%v expected %v,%v", key, value, 2, "b")
+	}
+	if actualValue, expectedValue := it.Next(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if key := it.Key(); key != 3 {
+		t.Errorf("Got %v expected %v", key, 3)
+	}
+
+	it = tree.IteratorAt(1)
+	if actualValue, expectedValue := it.Prev(), true; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if key := it.Key(); key != 1 {
+		t.Errorf("Got %v expected %v", key, 1)
+	}
+
+	it = tree.IteratorAt(-1)
+	if it.Next(); it.Key() != 1 {
+		t.Errorf("Got %v expected %v", it.Key(), 1)
+	}
+	it = tree.IteratorAt(4)
+	if it.Prev(); it.Key() != 4 {
+		t.Errorf("Got %v expected %v", it.Key(), 4)
+	}
+}
+
+func TestAVLTreeRangeIterator(t *testing.T) {
+	tree := NewWithIntComparator()
+	for _, key := range []int{13, 8, 17, 1, 11, 15, 25, 6, 22, 27} {
+		tree.Put(key, key*10)
+	}
+
+	tests := [][]interface{}{
+		{8, 17, util.IncludeFrom, "[8 11 13 15]"},
+		{8, 17, util.IncludeTo, "[11 13 15 17]"},
+		{8, 17, util.IncludeBoth, "[8 11 13 15 17]"},
+		{8, 17, util.IncludeNone, "[11 13 15]"},
+		{7, 18, util.IncludeNone, "[8 11 13 15 17]"},
+		{nil, 8, util.IncludeBoth, "[1 6 8]"},
+		{22, nil, util.IncludeNone, "[25 27]"},
+		{nil, nil, util.IncludeNone, "[1 6 8 11 13 15 17 22 25 27]"},
+		{30, 40, util.IncludeBoth, "[]"},
+		{17, 8, util.IncludeBoth, "[]"},
+		{11, 11, util.IncludeFrom, "[]"},
+		{11, 11, util.IncludeBoth, "[11]"},
+	}
+	for _, test := range tests {
+		inclusion := test[2].(util.Inclusion)
+		if actualValue, expectedValue := fmt.Sprint(tree.RangeKeys(test[0], test[1], inclusion)), test[3]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for %v", actualValue, expectedValue, test)
+		}
+		it := tree.RangeIterator(test[0], test[1], inclusion)
+		keys := []interface{}{}
+		for it.Last(); it.Key() != nil; it.Prev() {
+			keys = append([]interface{}{it.Key()}, keys...)
+		}
+		if actualValue, expectedValue := fmt.Sprint(keys), test[3]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for reverse %v", actualValue, expectedValue, test)
+		}
+		if actualValue, expectedValue := tree.CountRange(test[0], test[1], inclusion), len(tree.RangeKeys(test[0], test[1], inclusion)); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v for count %v", actualValue, expectedValue, test)
+		}
+	}
+
+	if actualValue, expectedValue := fmt.Sprint(tree.RangeValues(8, 13, util.IncludeBoth)), "[80 110 130]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	it := tree.RangeIterator(8, 17, util.IncludeFrom)
+	if actualValue, expectedValue := it.First(), true; actualValue != expectedValue || it.Key() != 8 {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), expectedValue, 8)
+	}
+	it.Next()
+	if actualValue, expectedValue := it.Prev(), true; actualValue != expectedValue || it.Key() != 8 {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), expectedValue, 8)
+	}
+	if actualValue, expectedValue := it.Prev(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Next(), true; actualValue != expectedValue || it.Key() != 8 {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), expectedValue, 8)
+	}
+	if actualValue, expectedValue := it.Last(), true; actualValue != expectedValue || it.Key() != 15 {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), expectedValue, 15)
+	}
+	if actualValue, expectedValue := it.Next(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Prev(), true; actualValue != expectedValue || it.Key() != 15 {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), expectedValue, 15)
+	}
+}
+
+func TestAVLTreeRangeIteratorOnEmpty(t *testing.T) {
+	tree := NewWithIntComparator()
+	it := tree.RangeIterator(1, 10, util.IncludeBoth)
+	for it.Next() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+	for it.Prev() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+	if actualValue := tree.CountRange(1, 10, util.IncludeBoth); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestAVLTreeSplit(t *testing.T) {
+	tree := NewWithIntComparator()
+	for i := 0; i < 100; i++ {
+		tree.Put(i, i)
+	}
+	less, greater := tree.Split(40)
+	assertValidAVLTree(t, less)
+	assertValidAVLTree(t, greater)
+	if actualValue, expectedValue := less.Size(), 40; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := greater.Size(), 60; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := less.Right().Key, 39; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := greater.Left().Key, 40; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := tree.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	less, greater = greater.Split(1000)
+	if actualValue, expectedValue := less.Size(), 60; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := greater.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	less, greater = NewWithIntComparator().Split(1)
+	if actualValue := less.Empty() && greater.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestAVLTreeJoin(t *testing.T) {
+	left := NewWithIntComparator()
+	right := NewWithIntComparator()
+	for i := 0; i < 5; i++ {
+		left.Put(i, i)
+	}
+	for i := 10; i < 200; i++ {
+		right.Put(i, i)
+	}
+	left.Join(right)
+	assertValidAVLTree(t, left)
+	if actualValue, expectedValue := left.Size(), 195; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := right.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	// 较大的key在调用方
+	small := NewWithIntComparator()
+	small.Put(-1, -1)
+	left.Join(small)
+	assertValidAVLTree(t, left)
+	if actualValue, expectedValue := left.Left().Key, -1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	empty := NewWithIntComparator()
+	empty.Join(left)
+	assertValidAVLTree(t, empty)
+	if actualValue, expectedValue := empty.Size(), 196; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic on overlapping key ranges")
+		}
+	}()
+	overlapping := NewWithIntComparator()
+	overlapping.Put(50, 50)
+	empty.Join(overlapping)
+}
+
+func TestAVLTreeSplitAndJoinRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for round := 0; round < 50; round++ {
+		tree := NewWithIntComparator()
+		for i := 0; i < r.Intn(300); i++ {
+			key := r.Intn(1000)
+			tree.Put(key, key)
+		}
+		keys := fmt.Sprint(tree.Keys())
+		size := tree.Size()
+		key := r.Intn(1000)
+		less, greater := tree.Split(key)
+		assertValidAVLTree(t, less)
+		assertValidAVLTree(t, greater)
+		if !less.Empty() && less.Right().Key.(int) >= key {
+			t.Errorf("Got %v expected less than %v", less.Right().Key, key)
+		}
+		if !greater.Empty() && greater.Left().Key.(int) < key {
+			t.Errorf("Got %v expected at least %v", greater.Left().Key, key)
+		}
+		greater.Join(less)
+		assertValidAVLTree(t, greater)
+		if actualValue, expectedValue := fmt.Sprint(greater.Keys()), keys; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := greater.Size(), size; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestAVLTreeAugment(t *testing.T) {
+	// augment维护子树中value的和
+	sums := map[*Node]int{}
+	sum := func(n *Node) int {
+		sum := n.Value.(int)
+		for _, child := range n.Children {
+			if child != nil {
+				sum += sums[child]
+			}
+		}
+		return sum
+	}
+	tree := NewWithAugment(util.IntComparator, func(n *Node) {
+		sums[n] = sum(n)
+	})
+	assert := func(tree *Tree) {
+		for it := tree.Iterator(); it.Next(); {
+			node := it.node
+			if actualValue, expectedValue := sums[node], sum(node); actualValue != expectedValue {
+				t.Errorf("Got %v expected %v for node %v", actualValue, expectedValue, node)
+				return
+			}
+		}
+	}
+
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < 1000; i++ {
+		key := r.Intn(300)
+		if r.Intn(3) == 0 {
+			tree.Remove(key)
+		} else {
+			tree.Put(key, r.Intn(100))
+		}
+	}
+	assert(tree)
+	less, greater := tree.Split(150)
+	assert(less)
+	assert(greater)
+	greater.Join(less)
+	assert(greater)
+	assert(greater.SelectFunc(func(key interface{}, value interface{}) bool { return key.(int)%2 == 0 }))
+}
+
+// assertValidAVLTree checks balance factors, subtree sizes, parent links and key order.
+func assertValidAVLTree(t *testing.T, tree *Tree) {
+	var check func(n *Node, parent *Node) int
+	check = func(n *Node, parent *Node) int {
+		if n == nil {
+			return 0
+		}
+		if n.Parent != parent {
+			t.Errorf("Got parent %v expected %v for node %v", n.Parent, parent, n)
+		}
+		left := check(n.Children[0], n)
+		right := check(n.Children[1], n)
+		if actualValue, expectedValue := n.balance, int8(right-left); actualValue != expectedValue {
+			t.Errorf("Got balance %v expected %v for node %v", actualValue, expectedValue, n)
+		}
+		if actualValue, expectedValue := n.size, 1+n.Children[0].Size()+n.Children[1].Size(); actualValue != expectedValue {
+			t.Errorf("Got size %v expected %v for node %v", actualValue, expectedValue, n)
+		}
+		if left > right {
+			return left + 1
+		}
+		return right + 1
+	}
+	if actualValue, expectedValue := check(tree.Root, nil), tree.Root.height(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v for tree height", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Root.Size(), tree.Size(); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v for tree size", actualValue, expectedValue)
+	}
+	keys := tree.Keys()
+	for i := 1; i < len(keys); i++ {
+		if tree.Comparator(keys[i-1], keys[i]) >= 0 {
+			t.Errorf("Keys out of order: %v", keys)
+			break
+		}
+	}
+}
+
+func TestAVLTreeSerializationKeyTypes(t *testing.T) {
+	ints := NewWithIntComparator()
+	ints.Put(10, "a")
+	ints.Put(2, "b")
+	ints.Put(33, "c")
+	floats := NewWith(func(a, b interface{}) int {
+		switch x, y := a.(float64), b.(float64); {
+		case x > y:
+			return 1
+		case x < y:
+			return -1
+		default:
+			return 0
+		}
+	})
+	floats.Put(1.5, 1)
+	floats.Put(-0.25, 2)
+
+	for _, tree := range []*Tree{ints, floats} {
+		data, err := tree.ToJSON()
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		keys := fmt.Sprintf("%#v", tree.Keys())
+		values := fmt.Sprint(tree.Values())
+		if err = tree.FromJSON(data); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprintf("%#v", tree.Keys()), keys; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Values()), values; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	if data, _ := ints.ToJSON(); string(data) != `[{"type":"int","key":2,"value":"b"},{"type":"int","key":10,"value":"a"},{"type":"int","key":33,"value":"c"}]` {
+		t.Errorf("Got %s", data)
+	}
+}
+
+func TestAVLTreeSerializationCustomKey(t *testing.T) {
+	type point struct{ X, Y int }
+	comparator := func(a, b interface{}) int {
+		p1, p2 := a.(point), b.(point)
+		if c := util.IntComparator(p1.X, p2.X); c != 0 {
+			return c
+		}
+		return util.IntComparator(p1.Y, p2.Y)
+	}
+	tree := NewWith(comparator)
+	tree.Put(point{2, 1}, "b")
+	tree.Put(point{1, 2}, "a")
+	data, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	if err = tree.FromJSON(data); err != util.ErrNoKeyDecoder {
+		t.Errorf("Got %v expected %v", err, util.ErrNoKeyDecoder)
+	}
+	if actualValue, expectedValue := tree.Size(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.KeyDecoder = func(data []byte) (interface{}, error) {
+		var p point
+		err := json.Unmarshal(data, &p)
+		return p, err
+	}
+	if err = tree.FromJSON(data); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[{1 2} {2 1}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestAVLTreeMarshalJSON(t *testing.T) {
+	type document struct {
+		Name  string
+		Index *Tree
+	}
+	doc := document{Name: "doc", Index: NewWithIntComparator()}
+	doc.Index.Put(2, "b")
+	doc.Index.Put(1, "a")
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(data), `{"Name":"doc","Index":[{"type":"int","key":1,"value":"a"},{"type":"int","key":2,"value":"b"}]}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	restored := document{Index: NewWithIntComparator()}
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(restored.Name, restored.Index.Keys(), restored.Index.Values()), "doc[1 2] [a b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	// 零值没有comparator, 无法解码
+	if err := json.Unmarshal(data, &document{}); err != util.ErrNotConstructed {
+		t.Errorf("Got %v expected %v", err, util.ErrNotConstructed)
+	}
+}
+
 func benchmarkGet(b *testing.B, tree *Tree, size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -740,3 +1251,7 @@ func BenchmarkAVLTreeRemove100000(b *testing.B) {
 	b.StartTimer()
 	benchmarkRemove(b, tree, size)
 }
+
+func TestAVLTreeSortedMap(t *testing.T) {
+	containertest.TestSortedMap(t, func() container.SortedMap { return NewWithIntComparator() })
+}
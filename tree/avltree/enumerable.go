@@ -0,0 +1,75 @@
+package avltree
+
+import "github.com/morganxf/algorithm/container"
+
+func assertEnumerableImplementation() {
+	var _ container.EnumerableWithKey = (*Tree)(nil)
+}
+
+// Each calls the given function once for each element, passing that element's key and value.
+func (t *Tree) Each(f func(key interface{}, value interface{})) {
+	it := t.Iterator()
+	for it.Next() {
+		f(it.Key(), it.Value())
+	}
+}
+
+// Map invokes the given function once for each element and returns a tree with the same
+// comparator containing the key/value pairs returned by the given function.
+func (t *Tree) Map(f func(key interface{}, value interface{}) (interface{}, interface{})) *Tree {
+	newTree := &Tree{Comparator: t.Comparator, KeyDecoder: t.KeyDecoder}
+	it := t.Iterator()
+	for it.Next() {
+		newTree.Put(f(it.Key(), it.Value()))
+	}
+	return newTree
+}
+
+// SelectFunc returns a tree with the same comparator containing all elements for which the given function returns a true value.
+// It is the Select of container.EnumerableWithKey, which is named differently since Select is the order statistic of the tree.
+func (t *Tree) SelectFunc(f func(key interface{}, value interface{}) bool) *Tree {
+	newTree := &Tree{Comparator: t.Comparator, KeyDecoder: t.KeyDecoder, augment: t.augment}
+	it := t.Iterator()
+	for it.Next() {
+		if f(it.Key(), it.Value()) {
+			newTree.Put(it.Key(), it.Value())
+		}
+	}
+	return newTree
+}
+
+// Any passes each element of the tree to the given function and
+// returns true if the function ever returns true for any element.
+func (t *Tree) Any(f func(key interface{}, value interface{}) bool) bool {
+	it := t.Iterator()
+	for it.Next() {
+		if f(it.Key(), it.Value()) {
+			return true
+		}
+	}
+	return false
+}
+
+// All passes each element of the tree to the given function and
+// returns true if the function returns true for all elements.
+func (t *Tree) All(f func(key interface{}, value interface{}) bool) bool {
+	it := t.Iterator()
+	for it.Next() {
+		if !f(it.Key(), it.Value()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Find passes each element of the tree to the given function and returns
+// the first (key,value) in key order for which the function is true or nil,nil otherwise.
+func (t *Tree) Find(f func(key interface{}, value interface{}) bool) (interface{}, interface{}) {
+	it := t.Iterator()
+	for it.Next() {
+		if f(it.Key(), it.Value()) {
+			return it.Key(), it.Value()
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,197 @@
+package avltree
+
+import (
+	"cmp"
+	"encoding/json"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+// TreeOf is the type-parameterized counterpart of Tree. It wraps a Tree, so both share the same behavior.
+type TreeOf[K, V any] struct {
+	tree       *Tree
+	comparator util.TypedComparator[K]
+}
+
+// IteratorOf is the type-parameterized counterpart of Iterator.
+type IteratorOf[K, V any] struct {
+	*Iterator
+}
+
+func NewOf[K, V any](comparator util.TypedComparator[K]) *TreeOf[K, V] {
+	return &TreeOf[K, V]{tree: NewWith(comparator.Untyped()), comparator: comparator}
+}
+
+// NewOrdered returns a tree ordered by the natural order of K.
+func NewOrdered[K cmp.Ordered, V any]() *TreeOf[K, V] {
+	return NewOf[K, V](util.OrderedComparator[K])
+}
+
+// Comparator returns the comparator the tree was created with.
+func (t *TreeOf[K, V]) Comparator() util.TypedComparator[K] {
+	return t.comparator
+}
+
+func (t *TreeOf[K, V]) Put(key K, value V) {
+	t.tree.Put(key, value)
+}
+
+func (t *TreeOf[K, V]) Get(key K) (V, bool) {
+	value, found := t.tree.Get(key)
+	v, _ := value.(V)
+	return v, found
+}
+
+func (t *TreeOf[K, V]) Remove(key K) {
+	t.tree.Remove(key)
+}
+
+func (t *TreeOf[K, V]) Empty() bool {
+	return t.tree.Empty()
+}
+
+func (t *TreeOf[K, V]) Size() int {
+	return t.tree.Size()
+}
+
+func (t *TreeOf[K, V]) Keys() []K {
+	keys := make([]K, 0, t.tree.Size())
+	it := t.tree.Iterator()
+	for it.Next() {
+		keys = append(keys, it.Key().(K))
+	}
+	return keys
+}
+
+func (t *TreeOf[K, V]) Values() []V {
+	values := make([]V, 0, t.tree.Size())
+	it := t.tree.Iterator()
+	for it.Next() {
+		v, _ := it.Value().(V)
+		values = append(values, v)
+	}
+	return values
+}
+
+func (t *TreeOf[K, V]) Clear() {
+	t.tree.Clear()
+}
+
+// Left returns the smallest key and its value, found is false if the tree is empty.
+func (t *TreeOf[K, V]) Left() (key K, value V, found bool) {
+	return entryOf[K, V](t.tree.Left())
+}
+
+// Right returns the largest key and its value, found is false if the tree is empty.
+func (t *TreeOf[K, V]) Right() (key K, value V, found bool) {
+	return entryOf[K, V](t.tree.Right())
+}
+
+func (t *TreeOf[K, V]) Floor(key K) (floorKey K, value V, found bool) {
+	node, _ := t.tree.Floor(key)
+	return entryOf[K, V](node)
+}
+
+func (t *TreeOf[K, V]) Ceiling(key K) (ceilingKey K, value V, found bool) {
+	node, _ := t.tree.Ceiling(key)
+	return entryOf[K, V](node)
+}
+
+func (t *TreeOf[K, V]) Select(index int) (key K, value V, found bool) {
+	node, _ := t.tree.Select(index)
+	return entryOf[K, V](node)
+}
+
+func (t *TreeOf[K, V]) Rank(key K) int {
+	return t.tree.Rank(key)
+}
+
+// RangeKeys returns the keys between from and to in order.
+// util.UnboundedFrom or util.UnboundedTo leave an end of the range unbounded, since a K can't be nil.
+func (t *TreeOf[K, V]) RangeKeys(from K, to K, inclusion util.Inclusion) []K {
+	keys := make([]K, 0)
+	it := t.tree.RangeIterator(from, to, inclusion)
+	for it.Next() {
+		keys = append(keys, it.Key().(K))
+	}
+	return keys
+}
+
+func (t *TreeOf[K, V]) CountRange(from K, to K, inclusion util.Inclusion) int {
+	return t.tree.CountRange(from, to, inclusion)
+}
+
+// Split is the type-parameterized counterpart of Tree.Split.
+func (t *TreeOf[K, V]) Split(key K) (*TreeOf[K, V], *TreeOf[K, V]) {
+	less, greater := t.tree.Split(key)
+	return &TreeOf[K, V]{tree: less, comparator: t.comparator}, &TreeOf[K, V]{tree: greater, comparator: t.comparator}
+}
+
+// Join is the type-parameterized counterpart of Tree.Join.
+func (t *TreeOf[K, V]) Join(other *TreeOf[K, V]) {
+	t.tree.Join(other.tree)
+}
+
+func (t *TreeOf[K, V]) Iterator() IteratorOf[K, V] {
+	return IteratorOf[K, V]{t.tree.Iterator()}
+}
+
+func (t *TreeOf[K, V]) String() string {
+	return t.tree.String()
+}
+
+// ToJSON produces the same output as Tree.ToJSON.
+func (t *TreeOf[K, V]) ToJSON() ([]byte, error) {
+	return t.tree.ToJSON()
+}
+
+// FromJSON decodes keys and values directly into K and V, so no KeyDecoder is needed.
+// A zero value TreeOf returns util.ErrNotConstructed.
+func (t *TreeOf[K, V]) FromJSON(data []byte) error {
+	if t.tree == nil {
+		return util.ErrNotConstructed
+	}
+	var entries []struct {
+		Key   K `json:"key"`
+		Value V `json:"value"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	t.tree.Clear()
+	for _, entry := range entries {
+		t.tree.Put(entry.Key, entry.Value)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, it is equivalent to ToJSON.
+func (t *TreeOf[K, V]) MarshalJSON() ([]byte, error) {
+	return t.ToJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it is equivalent to FromJSON.
+func (t *TreeOf[K, V]) UnmarshalJSON(data []byte) error {
+	return t.FromJSON(data)
+}
+
+// Key returns the current key, the zero value of K if the iterator is not on an element.
+func (it IteratorOf[K, V]) Key() K {
+	key, _ := it.Iterator.Key().(K)
+	return key
+}
+
+// Value returns the current value, the zero value of V if the iterator is not on an element.
+func (it IteratorOf[K, V]) Value() V {
+	value, _ := it.Iterator.Value().(V)
+	return value
+}
+
+func entryOf[K, V any](node *Node) (key K, value V, found bool) {
+	if node == nil {
+		return key, value, false
+	}
+	key, _ = node.Key.(K)
+	value, _ = node.Value.(V)
+	return key, value, true
+}
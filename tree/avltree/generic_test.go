@@ -0,0 +1,178 @@
+package avltree
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func TestTreeOfPutGetRemove(t *testing.T) {
+	tree := NewOrdered[int, string]()
+	tree.Put(5, "e")
+	tree.Put(6, "f")
+	tree.Put(7, "g")
+	tree.Put(3, "c")
+	tree.Put(4, "d")
+	tree.Put(1, "x")
+	tree.Put(2, "b")
+	tree.Put(1, "a") //overwrite
+
+	if actualValue := tree.Size(); actualValue != 7 {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 2 3 4 5 6 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := strings.Join(tree.Values(), ""), "abcdefg"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tests := []struct {
+		key   int
+		value string
+		found bool
+	}{
+		{1, "a", true},
+		{4, "d", true},
+		{7, "g", true},
+		{8, "", false},
+	}
+	for _, test := range tests {
+		if value, found := tree.Get(test.key); value != test.value || found != test.found {
+			t.Errorf("Got %v,%v expected %v,%v", value, found, test.value, test.found)
+		}
+	}
+
+	tree.Remove(4)
+	tree.Remove(8)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 2 3 5 6 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.Clear()
+	if actualValue := tree.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestTreeOfNavigation(t *testing.T) {
+	tree := NewOf[string, int](func(a, b string) int { return strings.Compare(b, a) })
+	for i, key := range []string{"a", "c", "e", "g"} {
+		tree.Put(key, i)
+	}
+
+	if key, value, found := tree.Left(); key != "g" || value != 3 || !found {
+		t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, "g", 3, true)
+	}
+	if key, value, found := tree.Right(); key != "a" || value != 0 || !found {
+		t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, "a", 0, true)
+	}
+	if key, _, found := tree.Floor("d"); key != "e" || !found {
+		t.Errorf("Got %v,%v expected %v,%v", key, found, "e", true)
+	}
+	if key, _, found := tree.Ceiling("d"); key != "c" || !found {
+		t.Errorf("Got %v,%v expected %v,%v", key, found, "c", true)
+	}
+	if key, _, found := tree.Ceiling("0"); key != "" || found {
+		t.Errorf("Got %v,%v expected %v,%v", key, found, "", false)
+	}
+	if key, _, found := tree.Select(1); key != "e" || !found {
+		t.Errorf("Got %v,%v expected %v,%v", key, found, "e", true)
+	}
+	if actualValue := tree.Rank("c"); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.RangeKeys("f", "a", util.IncludeBoth)), "[e c a]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := tree.CountRange("f", "a", util.IncludeFrom); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.RangeKeys("", "c", util.IncludeBoth|util.UnboundedFrom)), "[g e c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := tree.CountRange("e", "", util.IncludeNone|util.UnboundedTo); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue := tree.Comparator()("a", "b"); actualValue <= 0 {
+		t.Errorf("Got %v expected %v", actualValue, "> 0")
+	}
+
+	less, greater := tree.Split("c")
+	if actualValue, expectedValue := fmt.Sprint(less.Keys(), greater.Keys()), "[g e] [c a]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	greater.Join(less)
+	if actualValue, expectedValue := fmt.Sprint(greater.Keys()), "[g e c a]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestTreeOfIterator(t *testing.T) {
+	tree := NewOrdered[int, float64]()
+	tree.Put(3, 0.3)
+	tree.Put(1, 0.1)
+	tree.Put(2, 0.2)
+
+	it := tree.Iterator()
+	if key, value := it.Key(), it.Value(); key != 0 || value != 0 {
+		t.Errorf("Got %v,%v expected %v,%v", key, value, 0, 0)
+	}
+	count := 0
+	for it.Next() {
+		count++
+		if key, value := it.Key(), it.Value(); key != count || value != float64(count)/10 {
+			t.Errorf("Got %v,%v expected %v,%v", key, value, count, float64(count)/10)
+		}
+	}
+	if count != 3 {
+		t.Errorf("Got %v expected %v", count, 3)
+	}
+	for it.Prev() {
+		count--
+	}
+	if count != 0 {
+		t.Errorf("Got %v expected %v", count, 0)
+	}
+}
+
+func TestTreeOfSerialization(t *testing.T) {
+	tree := NewOrdered[int, string]()
+	tree.Put(2, "b")
+	tree.Put(1, "a")
+	tree.Put(3, "c")
+
+	json, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	restored := NewOrdered[int, string]()
+	if err := restored.FromJSON(json); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(restored.Keys(), restored.Values()), "[1 2 3] [a b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestTreeOfSeq(t *testing.T) {
+	tree := NewOrdered[int, string]()
+	tree.Put(2, "b")
+	tree.Put(1, "a")
+	tree.Put(3, "c")
+
+	result := ""
+	for key, value := range tree.AllSeq() {
+		result += fmt.Sprint(key) + value
+	}
+	for value := range tree.ValuesSeq() {
+		result += value
+	}
+	for key, value := range tree.RangeBackwardSeq(1, 3, util.IncludeTo) {
+		result += fmt.Sprint(key) + value
+	}
+	if expectedValue := "1a2b3cabc3c2b"; result != expectedValue {
+		t.Errorf("Got %v expected %v", result, expectedValue)
+	}
+}
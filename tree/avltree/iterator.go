@@ -16,6 +16,22 @@ func (t *Tree) Iterator() *Iterator {
 	return &Iterator{tree: t, node: nil, position: begin}
 }
 
+// IteratorAt returns an iterator positioned at the element with the given rank (0-based).
+// If index is negative the iterator is one-before-first, if index >= Size() it is one-past-the-end.
+func (t *Tree) IteratorAt(index int) *Iterator {
+	it := t.Iterator()
+	switch {
+	case index < 0:
+		it.Begin()
+	case index >= t.size:
+		it.End()
+	default:
+		it.node, _ = t.Select(index)
+		it.position = between
+	}
+	return it
+}
+
 func (it *Iterator) Next() bool {
 	switch it.position {
 	case begin:
@@ -0,0 +1,155 @@
+package avltree
+
+import (
+	"github.com/morganxf/algorithm/container"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func assertRangeIteratorImplementation() {
+	var _ container.ReverseIteratorWithKey = (*RangeIterator)(nil)
+}
+
+// RangeIterator is a stateful iterator over the keys of a tree within a range.
+// A nil from or to, or util.UnboundedFrom or util.UnboundedTo, leaves that end of the range unbounded.
+type RangeIterator struct {
+	tree      *Tree
+	from      interface{}
+	to        interface{}
+	inclusion util.Inclusion
+	node      *Node
+	position  position
+}
+
+// RangeIterator returns an iterator over the keys between from and to.
+// Seeking the first or last element of the range is O(log n).
+func (t *Tree) RangeIterator(from interface{}, to interface{}, inclusion util.Inclusion) *RangeIterator {
+	from, to = inclusion.Bounds(from, to)
+	return &RangeIterator{tree: t, from: from, to: to, inclusion: inclusion, position: begin}
+}
+
+// RangeKeys returns the keys between from and to in order.
+func (t *Tree) RangeKeys(from interface{}, to interface{}, inclusion util.Inclusion) []interface{} {
+	keys := make([]interface{}, 0)
+	it := t.RangeIterator(from, to, inclusion)
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+// RangeValues returns the values whose keys are between from and to, in key order.
+func (t *Tree) RangeValues(from interface{}, to interface{}, inclusion util.Inclusion) []interface{} {
+	values := make([]interface{}, 0)
+	it := t.RangeIterator(from, to, inclusion)
+	for it.Next() {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
+// CountRange returns the number of keys between from and to in O(log n).
+func (t *Tree) CountRange(from interface{}, to interface{}, inclusion util.Inclusion) int {
+	from, to = inclusion.Bounds(from, to)
+	// 上界之前的节点数目 - 下界之前的节点数目
+	high := t.size
+	if to != nil {
+		high = t.rank(to, inclusion&util.IncludeTo != 0)
+	}
+	low := 0
+	if from != nil {
+		low = t.rank(from, inclusion&util.IncludeFrom == 0)
+	}
+	if high < low {
+		return 0
+	}
+	return high - low
+}
+
+func (it *RangeIterator) Next() bool {
+	switch it.position {
+	case begin:
+		if it.from == nil {
+			it.node = it.tree.Left()
+		} else {
+			it.node = it.tree.ceiling(it.from, it.inclusion&util.IncludeFrom == 0)
+		}
+		it.position = between
+	case between:
+		it.node = it.node.Next()
+	}
+	if it.node == nil || !it.belowTo(it.node.Key) {
+		it.End()
+		return false
+	}
+	return true
+}
+
+func (it *RangeIterator) Prev() bool {
+	switch it.position {
+	case end:
+		if it.to == nil {
+			it.node = it.tree.Right()
+		} else {
+			it.node = it.tree.floor(it.to, it.inclusion&util.IncludeTo == 0)
+		}
+		it.position = between
+	case between:
+		it.node = it.node.Prev()
+	}
+	if it.node == nil || !it.aboveFrom(it.node.Key) {
+		it.Begin()
+		return false
+	}
+	return true
+}
+
+func (it *RangeIterator) Key() interface{} {
+	if it.node == nil {
+		return nil
+	}
+	return it.node.Key
+}
+
+func (it *RangeIterator) Value() interface{} {
+	if it.node == nil {
+		return nil
+	}
+	return it.node.Value
+}
+
+func (it *RangeIterator) Begin() {
+	it.node = nil
+	it.position = begin
+}
+
+func (it *RangeIterator) End() {
+	it.node = nil
+	it.position = end
+}
+
+func (it *RangeIterator) First() bool {
+	it.Begin()
+	return it.Next()
+}
+
+func (it *RangeIterator) Last() bool {
+	it.End()
+	return it.Prev()
+}
+
+func (it *RangeIterator) aboveFrom(key interface{}) bool {
+	if it.from == nil {
+		return true
+	}
+	cmp := it.tree.Comparator(key, it.from)
+	return cmp > 0 || (cmp == 0 && it.inclusion&util.IncludeFrom != 0)
+}
+
+func (it *RangeIterator) belowTo(key interface{}) bool {
+	if it.to == nil {
+		return true
+	}
+	cmp := it.tree.Comparator(key, it.to)
+	return cmp < 0 || (cmp == 0 && it.inclusion&util.IncludeTo != 0)
+}
@@ -0,0 +1,78 @@
+package avltree
+
+import (
+	"iter"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+// AllSeq returns an iterator over the key/value pairs of the tree in key order, for use with range.
+func (t *Tree) AllSeq() iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		for node := t.Left(); node != nil; node = node.Next() {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// BackwardSeq returns an iterator over the key/value pairs of the tree in reverse key order.
+func (t *Tree) BackwardSeq() iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		for node := t.Right(); node != nil; node = node.Prev() {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// KeysSeq returns an iterator over the keys of the tree in order.
+func (t *Tree) KeysSeq() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for node := t.Left(); node != nil; node = node.Next() {
+			if !yield(node.Key) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesSeq returns an iterator over the values of the tree in key order.
+func (t *Tree) ValuesSeq() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for node := t.Left(); node != nil; node = node.Next() {
+			if !yield(node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// RangeSeq returns an iterator over the key/value pairs whose keys are between from and to, in key order.
+// The bounds behave as in RangeIterator.
+func (t *Tree) RangeSeq(from interface{}, to interface{}, inclusion util.Inclusion) iter.Seq2[interface{}, interface{}] {
+	from, to = inclusion.Bounds(from, to)
+	return func(yield func(interface{}, interface{}) bool) {
+		it := RangeIterator{tree: t, from: from, to: to, inclusion: inclusion, position: begin}
+		for it.Next() {
+			if !yield(it.node.Key, it.node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// RangeBackwardSeq returns an iterator over the key/value pairs whose keys are between from and to, in reverse key order.
+func (t *Tree) RangeBackwardSeq(from interface{}, to interface{}, inclusion util.Inclusion) iter.Seq2[interface{}, interface{}] {
+	from, to = inclusion.Bounds(from, to)
+	return func(yield func(interface{}, interface{}) bool) {
+		it := RangeIterator{tree: t, from: from, to: to, inclusion: inclusion, position: end}
+		for it.Prev() {
+			if !yield(it.node.Key, it.node.Value) {
+				return
+			}
+		}
+	}
+}
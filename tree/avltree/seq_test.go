@@ -0,0 +1,101 @@
+package avltree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func TestTreeAllSeq(t *testing.T) {
+	tree := NewWithIntComparator()
+	for _, key := range []int{5, 1, 4, 2, 3} {
+		tree.Put(key, fmt.Sprint(key))
+	}
+
+	keys, values := []interface{}{}, []interface{}{}
+	for key, value := range tree.AllSeq() {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys, values), "[1 2 3 4 5] [1 2 3 4 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	keys = keys[:0]
+	for key := range tree.BackwardSeq() {
+		keys = append(keys, key)
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[5 4 3 2 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	keys = keys[:0]
+	for key := range tree.KeysSeq() {
+		if key == 3 {
+			break
+		}
+		keys = append(keys, key)
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[1 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	values = values[:0]
+	for value := range tree.ValuesSeq() {
+		values = append(values, value)
+	}
+	if actualValue, expectedValue := fmt.Sprint(values), "[1 2 3 4 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestTreeRangeSeq(t *testing.T) {
+	tree := NewWithIntComparator()
+	for key := 1; key <= 9; key += 2 {
+		tree.Put(key, key)
+	}
+
+	tests := [][]interface{}{
+		{3, 7, util.IncludeBoth, "[3 5 7]", "[7 5 3]"},
+		{3, 7, util.IncludeNone, "[5]", "[5]"},
+		{3, 7, util.IncludeFrom, "[3 5]", "[5 3]"},
+		{3, 7, util.IncludeTo, "[5 7]", "[7 5]"},
+		{2, 8, util.IncludeNone, "[3 5 7]", "[7 5 3]"},
+		{nil, 4, util.IncludeNone, "[1 3]", "[3 1]"},
+		{6, nil, util.IncludeNone, "[7 9]", "[9 7]"},
+		{7, 3, util.IncludeBoth, "[]", "[]"},
+	}
+	for _, test := range tests {
+		keys := []interface{}{}
+		for key := range tree.RangeSeq(test[0], test[1], test[2].(util.Inclusion)) {
+			keys = append(keys, key)
+		}
+		if actualValue := fmt.Sprint(keys); actualValue != test[3] {
+			t.Errorf("Got %v expected %v", actualValue, test[3])
+		}
+		keys = keys[:0]
+		for key := range tree.RangeBackwardSeq(test[0], test[1], test[2].(util.Inclusion)) {
+			keys = append(keys, key)
+		}
+		if actualValue := fmt.Sprint(keys); actualValue != test[4] {
+			t.Errorf("Got %v expected %v", actualValue, test[4])
+		}
+	}
+}
+
+func TestTreeAllSeqAllocations(t *testing.T) {
+	tree := NewWithIntComparator()
+	for i := 0; i < 1000; i++ {
+		tree.Put(i, i)
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		for range tree.AllSeq() {
+		}
+		for range tree.RangeSeq(100, 900, util.IncludeBoth) {
+		}
+	})
+	if allocs > 4 {
+		t.Errorf("Got %v allocations expected at most %v", allocs, 4)
+	}
+}
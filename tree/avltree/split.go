@@ -0,0 +1,166 @@
+package avltree
+
+// Split cuts the tree at key in O(log n). The first returned tree holds the keys less than key,
+// the second one the keys greater than or equal to key. Both share the comparator, key decoder and augment function of t.
+// Nodes are moved rather than copied, so t is empty afterwards.
+func (t *Tree) Split(key interface{}) (*Tree, *Tree) {
+	less := &Tree{Comparator: t.Comparator, KeyDecoder: t.KeyDecoder, augment: t.augment}
+	greater := &Tree{Comparator: t.Comparator, KeyDecoder: t.KeyDecoder, augment: t.augment}
+	l, _, r, _ := t.split(t.Root, t.Root.height(), key)
+	less.setRoot(l)
+	greater.setRoot(r)
+	t.Clear()
+	return less, greater
+}
+
+// Join moves all the nodes of other into t in O(log n). The key ranges of the two trees must not
+// overlap, either tree may hold the smaller keys. other is empty afterwards.
+func (t *Tree) Join(other *Tree) {
+	if other.Empty() {
+		return
+	}
+	if t.Empty() {
+		t.setRoot(other.Root)
+		other.Clear()
+		return
+	}
+	left, right := t, other
+	if t.Comparator(t.Right().Key, other.Left().Key) >= 0 {
+		if t.Comparator(other.Right().Key, t.Left().Key) >= 0 {
+			panic("Overlapping key ranges, cannot join")
+		}
+		left, right = other, t
+	}
+	// 取出右树的最小node作为连接两棵树的中间node
+	rightRoot := right.Root
+	mid := &Node{}
+	t.removeMin(&rightRoot, &mid.Key, &mid.Value)
+	root, _ := t.join(left.Root, left.Root.height(), mid, rightRoot, rightRoot.height())
+	t.setRoot(root)
+	other.Clear()
+}
+
+func (t *Tree) setRoot(root *Node) {
+	if root != nil {
+		root.Parent = nil
+	}
+	t.Root = root
+	t.size = root.Size()
+}
+
+// split按key把高度为height的子树n分为小于key和大于等于key的两棵子树，同时返回它们的高度
+func (t *Tree) split(n *Node, height int, key interface{}) (*Node, int, *Node, int) {
+	if n == nil {
+		return nil, 0, nil, 0
+	}
+	left, right := n.Children[0], n.Children[1]
+	leftHeight, rightHeight := n.childHeights(height)
+	n.Children = [2]*Node{}
+	if t.Comparator(n.Key, key) < 0 {
+		// n和左子树都属于小于key的部分
+		l, lh, r, rh := t.split(right, rightHeight, key)
+		l, lh = t.join(left, leftHeight, n, l, lh)
+		return l, lh, r, rh
+	}
+	l, lh, r, rh := t.split(left, leftHeight, key)
+	r, rh = t.join(r, rh, n, right, rightHeight)
+	return l, lh, r, rh
+}
+
+// join以mid为中间node连接left和right两棵子树, left中的key都小于mid.Key, right中的key都大于mid.Key
+// 返回新子树的根和高度
+func (t *Tree) join(left *Node, leftHeight int, mid *Node, right *Node, rightHeight int) (*Node, int) {
+	var root *Node
+	var height int
+	switch {
+	case leftHeight > rightHeight+1:
+		// 沿着left的右侧路径向下，找到与right高度相近的子树
+		root, height = left, leftHeight
+		if t.joinSpine(1, &root, leftHeight, nil, mid, right, rightHeight) {
+			height++
+		}
+	case rightHeight > leftHeight+1:
+		root, height = right, rightHeight
+		if t.joinSpine(-1, &root, rightHeight, nil, mid, left, leftHeight) {
+			height++
+		}
+	default:
+		mid.setChildren(left, right)
+		mid.balance = int8(rightHeight - leftHeight)
+		t.resize(mid)
+		root, height = mid, leftHeight+1
+		if rightHeight > leftHeight {
+			height = rightHeight + 1
+		}
+	}
+	root.Parent = nil
+	return root, height
+}
+
+// joinSpine沿着c方向的路径向下，在高度不超过shortHeight+1的子树处以mid连接short，再自底向上rebalance
+// c == +1, short在右侧; c == -1, short在左侧
+// true: 子树高度增加
+func (t *Tree) joinSpine(c int8, target **Node, height int, parent *Node, mid *Node, short *Node, shortHeight int) bool {
+	d := (c + 1) / 2
+	cur := *target
+	if height <= shortHeight+1 {
+		mid.Parent = parent
+		if d == 1 {
+			mid.setChildren(cur, short)
+		} else {
+			mid.setChildren(short, cur)
+		}
+		mid.balance = c * int8(shortHeight-height)
+		t.resize(mid)
+		*target = mid
+		return true
+	}
+	var childHeight int
+	if d == 1 {
+		_, childHeight = cur.childHeights(height)
+	} else {
+		childHeight, _ = cur.childHeights(height)
+	}
+	grew := t.joinSpine(c, &cur.Children[d], childHeight, cur, mid, short, shortHeight)
+	t.resize(cur)
+	if grew {
+		// 与put相同，子树高度增加可能导致不平衡
+		return t.putRebalance(c, target)
+	}
+	return false
+}
+
+func (n *Node) setChildren(left *Node, right *Node) {
+	n.Children[0], n.Children[1] = left, right
+	if left != nil {
+		left.Parent = n
+	}
+	if right != nil {
+		right.Parent = n
+	}
+}
+
+// height沿着较高的孩子向下计算子树高度, O(log n)
+func (n *Node) height() int {
+	height := 0
+	for ; n != nil; height++ {
+		if n.balance > 0 {
+			n = n.Children[1]
+		} else {
+			n = n.Children[0]
+		}
+	}
+	return height
+}
+
+// childHeights根据balance由当前子树高度推算左右子树的高度
+func (n *Node) childHeights(height int) (int, int) {
+	switch {
+	case n.balance < 0:
+		return height - 1, height - 2
+	case n.balance > 0:
+		return height - 2, height - 1
+	default:
+		return height - 1, height - 1
+	}
+}
@@ -8,21 +8,40 @@ import (
 	"github.com/morganxf/algorithm/util"
 )
 
+// DefaultArity is the number of children of every node of a heap created by NewWith.
+const DefaultArity = 2
+
 type Heap struct {
-	list       *arraylist.List
-	Comparator util.Comparator
+	list         *arraylist.List
+	arity        int
+	Comparator   util.Comparator
+	ValueDecoder util.ValueDecoder // 可选, FromJSON用于还原值, 默认整数还原为int, 其他数字为float64
 }
 
 func NewWith(comparator util.Comparator) *Heap {
-	return &Heap{list: arraylist.New(), Comparator: comparator}
+	return NewWithArity(DefaultArity, comparator)
+}
+
+// NewWithArity returns a d-ary heap, every node has up to d children. A larger d makes the heap shallower,
+// which speeds up Push at the cost of more comparisons per level in Pop. It panics if d is less than 2.
+func NewWithArity(d int, comparator util.Comparator) *Heap {
+	if d < 2 {
+		panic("Invalid arity, should be at least 2")
+	}
+	return &Heap{list: arraylist.New(), arity: d, Comparator: comparator}
 }
 
 func NewWithIntComparator() *Heap {
-	return &Heap{list: arraylist.New(), Comparator: util.IntComparator}
+	return NewWith(util.IntComparator)
 }
 
 func NewWithStringComparator() *Heap {
-	return &Heap{list: arraylist.New(), Comparator: util.StringComparator}
+	return NewWith(util.StringComparator)
+}
+
+// Arity returns the number of children of every node.
+func (heap *Heap) Arity() int {
+	return heap.arity
 }
 
 func (heap *Heap) Push(values ...interface{}) {
@@ -33,7 +52,7 @@ func (heap *Heap) Push(values ...interface{}) {
 		heap.list.Add(values...)
 		// lastChildParentIndex 是最后一个孩子父亲的index
 		// 以此为起点，遍历到根节点，调整树结构
-		lastChildParentIndex := heap.list.Size()/2 - 1
+		lastChildParentIndex := heap.parentIndex(heap.list.Size() - 1)
 		for i := lastChildParentIndex; i >= 0; i-- {
 			heap.bubbleDownIndex(i)
 		}
@@ -54,6 +73,16 @@ func (heap *Heap) Pop() (interface{}, bool) {
 	return value, true
 }
 
+// Drain pops every value and returns them in comparator order, leaving the heap empty.
+func (heap *Heap) Drain() []interface{} {
+	values := make([]interface{}, 0, heap.Size())
+	for !heap.Empty() {
+		value, _ := heap.Pop()
+		values = append(values, value)
+	}
+	return values
+}
+
 func (heap *Heap) Peek() (interface{}, bool) {
 	return heap.list.Get(0)
 }
@@ -84,22 +113,22 @@ func (heap *Heap) String() string {
 	return str
 }
 
-// 二叉堆 以0为起始点
-// leftChildIndex = 2*i+1, rightChildIndex = 2*i+2
-// parentIndex = (childIndex-1)/2
-func (heap *Heap) bubbleUp() {
-	index := heap.list.Size() - 1
-	// 遍历index节点到root节点之间的路径，次数为树的高度
-	for parentIndex := (index - 1) >> 1; parentIndex >= 0; parentIndex = (index - 1) >> 1 {
-		indexValue, _ := heap.list.Get(index)
-		parentValue, _ := heap.list.Get(parentIndex)
-		if heap.Comparator(parentValue, indexValue) <= 0 {
-			// 小于父亲节点
-			break
-		}
-		heap.list.Swap(index, parentIndex)
-		index = parentIndex
+// d叉堆 以0为起始点
+// childIndex = d*i+1 ... d*i+d
+// parentIndex = (childIndex-1)/d, 二叉堆即d = 2
+func (heap *Heap) parentIndex(index int) int {
+	if index <= 0 {
+		return -1
 	}
+	return (index - 1) / heap.arity
+}
+
+func (heap *Heap) firstChildIndex(index int) int {
+	return index*heap.arity + 1
+}
+
+func (heap *Heap) bubbleUp() {
+	heap.sifter().up(heap.list.Size() - 1)
 }
 
 func (heap *Heap) bubbleDown() {
@@ -107,20 +136,58 @@ func (heap *Heap) bubbleDown() {
 }
 
 func (heap *Heap) bubbleDownIndex(index int) {
-	size := heap.list.Size()
-	for leftIndex := index<<1 + 1; leftIndex < size; leftIndex = index<<1 + 1 {
-		rightIndex := leftIndex + 1
-		smallerIndex := leftIndex
-		leftValue, _ := heap.list.Get(leftIndex)
-		rightValue, _ := heap.list.Get(rightIndex)
-		if rightIndex < size && heap.Comparator(leftValue, rightValue) > 0 {
-			smallerIndex = rightIndex
+	heap.sifter().down(index)
+}
+
+func (heap *Heap) sifter() sifter {
+	return sifter{
+		arity: heap.arity,
+		size:  heap.list.Size(),
+		compare: func(i, j int) int {
+			iValue, _ := heap.list.Get(i)
+			jValue, _ := heap.list.Get(j)
+			return heap.Comparator(iValue, jValue)
+		},
+		swap: heap.list.Swap,
+	}
+}
+
+// sifter 描述一个以0为起始点的d叉堆，通过下标比较和交换元素，Heap和IndexedHeap共用同一套调整逻辑
+type sifter struct {
+	arity   int
+	size    int
+	compare func(i, j int) int
+	swap    func(i, j int)
+}
+
+// up将index处的元素向上调整，返回最终的位置
+func (s sifter) up(index int) int {
+	// 遍历index节点到root节点之间的路径，次数为树的高度
+	for index > 0 {
+		parentIndex := (index - 1) / s.arity
+		if s.compare(parentIndex, index) <= 0 {
+			// 小于父亲节点
+			break
+		}
+		s.swap(index, parentIndex)
+		index = parentIndex
+	}
+	return index
+}
+
+// down将index处的元素向下调整
+func (s sifter) down(index int) {
+	for firstIndex := index*s.arity + 1; firstIndex < s.size; firstIndex = index*s.arity + 1 {
+		// 在所有孩子中找到最小的
+		smallerIndex := firstIndex
+		for childIndex := firstIndex + 1; childIndex < firstIndex+s.arity && childIndex < s.size; childIndex++ {
+			if s.compare(smallerIndex, childIndex) > 0 {
+				smallerIndex = childIndex
+			}
 		}
-		indexValue, _ := heap.list.Get(index)
-		smallerValue, _ := heap.list.Get(smallerIndex)
 		// 向下迭代，直到父亲小于孩子，或者迭代到最后一个节点
-		if heap.Comparator(indexValue, smallerValue) > 0 {
-			heap.list.Swap(index, smallerIndex)
+		if s.compare(index, smallerIndex) > 0 {
+			s.swap(index, smallerIndex)
 		} else {
 			break
 		}
@@ -5,8 +5,13 @@
 package binaryheap
 
 import (
+	"encoding/json"
+	"fmt"
 	"math/rand"
+	"sort"
 	"testing"
+
+	"github.com/morganxf/algorithm/util"
 )
 
 func TestBinaryHeapPush(t *testing.T) {
@@ -259,6 +264,113 @@ func TestBinaryHeapIteratorLast(t *testing.T) {
 	}
 }
 
+func TestBinaryHeapArity(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		heap := NewWithArity(d, util.IntComparator)
+		if actualValue := heap.Arity(); actualValue != d {
+			t.Errorf("Got %v expected %v", actualValue, d)
+		}
+
+		r := rand.New(rand.NewSource(int64(d)))
+		expected := []int{}
+		bulk := []interface{}{}
+		for i := 0; i < 500; i++ {
+			value := r.Intn(100)
+			expected = append(expected, value)
+			if i%2 == 0 {
+				heap.Push(value)
+			} else {
+				bulk = append(bulk, value)
+			}
+		}
+		heap.Push(bulk...)
+		sort.Ints(expected)
+
+		it := heap.SortedIterator()
+		for i := 0; it.Next(); i++ {
+			if actualValue := it.Value(); actualValue != expected[i] {
+				t.Errorf("Got %v expected %v", actualValue, expected[i])
+				break
+			}
+		}
+		for _, expectedValue := range expected {
+			if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+				break
+			}
+		}
+	}
+}
+
+func TestBinaryHeapArityInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got %v expected %v", r, "panic")
+		}
+	}()
+	NewWithArity(1, util.IntComparator)
+}
+
+func TestBinaryHeapSortedIterator(t *testing.T) {
+	heap := NewWithIntComparator()
+	it := heap.SortedIterator()
+	for it.Next() {
+		t.Errorf("Shouldn't iterate on empty heap")
+	}
+
+	heap.Push(15, 20, 3, 1, 2, 8, 8, 13)
+	values := fmt.Sprint(heap.Values())
+	it = heap.SortedIterator()
+	sorted := []interface{}{}
+	for it.Next() {
+		if actualValue, expectedValue := it.Index(), len(sorted); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		sorted = append(sorted, it.Value())
+	}
+	if actualValue, expectedValue := fmt.Sprint(sorted), "[1 2 3 8 8 13 15 20]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := it.Next(), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(heap.Values()), values; actualValue != expectedValue {
+		t.Errorf("Heap modified. Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue, expectedValue := it.First(), true; actualValue != expectedValue || it.Value() != 1 || it.Index() != 0 {
+		t.Errorf("Got %v,%v,%v expected %v,%v,%v", actualValue, it.Value(), it.Index(), expectedValue, 1, 0)
+	}
+	it.Next()
+	it.Next()
+	if actualValue, expectedValue := it.Value(), 3; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBinaryHeapDrain(t *testing.T) {
+	heap := NewWithIntComparator()
+	if actualValue := heap.Drain(); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+	rand.Seed(3)
+	for i := 0; i < 1000; i++ {
+		heap.Push(int(rand.Int31n(100)))
+	}
+	values := heap.Drain()
+	if actualValue, expectedValue := len(values), 1000; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i := 1; i < len(values); i++ {
+		if values[i-1].(int) > values[i].(int) {
+			t.Errorf("Values out of order. prev: %v current: %v", values[i-1], values[i])
+		}
+	}
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
 func TestBinaryHeapSerialization(t *testing.T) {
 	heap := NewWithStringComparator()
 
@@ -291,6 +403,70 @@ func TestBinaryHeapSerialization(t *testing.T) {
 	assert()
 }
 
+func TestBinaryHeapSerializationValueTypes(t *testing.T) {
+	heap := NewWithIntComparator()
+	heap.Push(3, 1, 2)
+	data, err := heap.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	restored := NewWithIntComparator()
+	if err := restored.FromJSON(data); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(restored.Drain()), "[1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// 整数形式的float64需要ValueDecoder
+	floats := NewWith(util.TypedComparator[float64](util.OrderedComparator[float64]).Untyped())
+	if err := floats.FromJSON([]byte("[2.5, 1, 3]")); err == nil {
+		t.Errorf("Expected an error for values the comparator can't compare")
+	}
+	if actualValue := floats.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	floats.ValueDecoder = func(data []byte) (interface{}, error) {
+		var f float64
+		err := json.Unmarshal(data, &f)
+		return f, err
+	}
+	if err := floats.FromJSON([]byte("[2.5, 1, 3]")); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(floats.Drain()), "[1 2.5 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	var zero Heap
+	if err := json.Unmarshal(data, &zero); err != util.ErrNotConstructed {
+		t.Errorf("Got %v expected %v", err, util.ErrNotConstructed)
+	}
+}
+
+func TestBinaryHeapMarshalJSON(t *testing.T) {
+	type document struct {
+		Name  string
+		Queue *Heap
+	}
+	restored := document{Queue: NewWithStringComparator()}
+	// 数组不是堆序, 反序列化后重建堆
+	if err := json.Unmarshal([]byte(`{"Name":"doc","Queue":["c","b","d","a"]}`), &restored); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, ok := restored.Queue.Peek(); actualValue != "a" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "a")
+	}
+
+	data, err := json.Marshal(restored)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(data), `{"Name":"doc","Queue":["a","b","d","c"]}`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
 func benchmarkPush(b *testing.B, heap *Heap, size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -307,6 +483,16 @@ func benchmarkPop(b *testing.B, heap *Heap, size int) {
 	}
 }
 
+// benchmarkPushAndPop keeps the heap at its prefilled size, so every Pop walks the full height.
+func benchmarkPushAndPop(b *testing.B, heap *Heap, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			heap.Push(n)
+			heap.Pop()
+		}
+	}
+}
+
 func BenchmarkBinaryHeapPop100(b *testing.B) {
 	b.StopTimer()
 	size := 100
@@ -391,3 +577,60 @@ func BenchmarkBinaryHeapPush100000(b *testing.B) {
 	b.StartTimer()
 	benchmarkPush(b, heap, size)
 }
+
+func BenchmarkBinaryHeapArity2Push10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	heap := NewWithArity(2, util.IntComparator)
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkBinaryHeapArity4Push10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	heap := NewWithArity(4, util.IntComparator)
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkBinaryHeapArity8Push10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	heap := NewWithArity(8, util.IntComparator)
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkBinaryHeapArity2PushAndPop10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	heap := NewWithArity(2, util.IntComparator)
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPushAndPop(b, heap, size)
+}
+
+func BenchmarkBinaryHeapArity4PushAndPop10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	heap := NewWithArity(4, util.IntComparator)
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPushAndPop(b, heap, size)
+}
+
+func BenchmarkBinaryHeapArity8PushAndPop10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	heap := NewWithArity(8, util.IntComparator)
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPushAndPop(b, heap, size)
+}
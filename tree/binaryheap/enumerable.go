@@ -0,0 +1,79 @@
+package binaryheap
+
+import "github.com/morganxf/algorithm/container"
+
+func assertEnumerableImplementation() {
+	var _ container.EnumerableWithIndex = (*Heap)(nil)
+}
+
+// Each calls the given function once for each element in storage order, passing that element's index and value.
+func (heap *Heap) Each(f func(index int, value interface{})) {
+	it := heap.Iterator()
+	for it.Next() {
+		f(it.Index(), it.Value())
+	}
+}
+
+// Map invokes the given function once for each element and returns a heap with the same
+// comparator containing the values returned by the given function.
+func (heap *Heap) Map(f func(index int, value interface{}) interface{}) *Heap {
+	values := make([]interface{}, 0, heap.Size())
+	it := heap.Iterator()
+	for it.Next() {
+		values = append(values, f(it.Index(), it.Value()))
+	}
+	newHeap := NewWithArity(heap.arity, heap.Comparator)
+	newHeap.Push(values...)
+	return newHeap
+}
+
+// Select returns a heap with the same comparator containing all elements for which the given function returns a true value.
+func (heap *Heap) Select(f func(index int, value interface{}) bool) *Heap {
+	values := make([]interface{}, 0)
+	it := heap.Iterator()
+	for it.Next() {
+		if f(it.Index(), it.Value()) {
+			values = append(values, it.Value())
+		}
+	}
+	newHeap := NewWithArity(heap.arity, heap.Comparator)
+	newHeap.ValueDecoder = heap.ValueDecoder
+	newHeap.Push(values...)
+	return newHeap
+}
+
+// Any passes each element of the heap to the given function and
+// returns true if the function ever returns true for any element.
+func (heap *Heap) Any(f func(index int, value interface{}) bool) bool {
+	it := heap.Iterator()
+	for it.Next() {
+		if f(it.Index(), it.Value()) {
+			return true
+		}
+	}
+	return false
+}
+
+// All passes each element of the heap to the given function and
+// returns true if the function returns true for all elements.
+func (heap *Heap) All(f func(index int, value interface{}) bool) bool {
+	it := heap.Iterator()
+	for it.Next() {
+		if !f(it.Index(), it.Value()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Find passes each element of the heap to the given function and returns
+// the first (index,value) in storage order for which the function is true or -1,nil otherwise.
+func (heap *Heap) Find(f func(index int, value interface{}) bool) (int, interface{}) {
+	it := heap.Iterator()
+	for it.Next() {
+		if f(it.Index(), it.Value()) {
+			return it.Index(), it.Value()
+		}
+	}
+	return -1, nil
+}
@@ -0,0 +1,94 @@
+package binaryheap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func newEnumerableHeap() *Heap {
+	heap := NewWithIntComparator()
+	heap.Push(3, 1, 2) // [1,3,2]
+	return heap
+}
+
+func TestHeapEach(t *testing.T) {
+	heap := newEnumerableHeap()
+	values := heap.Values()
+	count := 0
+	heap.Each(func(index int, value interface{}) {
+		if index != count || value != values[index] {
+			t.Errorf("Got %v,%v expected %v,%v", index, value, count, values[count])
+		}
+		count++
+	})
+	if count != 3 {
+		t.Errorf("Got %v expected %v", count, 3)
+	}
+}
+
+func TestHeapMap(t *testing.T) {
+	heap := newEnumerableHeap()
+	mapped := heap.Map(func(index int, value interface{}) interface{} {
+		return -value.(int)
+	})
+	if actualValue, expectedValue := fmt.Sprint(mapped.Drain()), "[-3 -2 -1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := heap.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+}
+
+func TestHeapSelect(t *testing.T) {
+	heap := newEnumerableHeap()
+	selected := heap.Select(func(index int, value interface{}) bool {
+		return value.(int) > 1
+	})
+	selected.Push(0)
+	if actualValue, expectedValue := fmt.Sprint(selected.Drain()), "[0 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestHeapMapAndSelectArity(t *testing.T) {
+	heap := NewWithArity(4, util.IntComparator)
+	heap.Push(5, 1, 4, 2, 3, 6)
+	mapped := heap.Map(func(index int, value interface{}) interface{} { return value })
+	selected := heap.Select(func(index int, value interface{}) bool { return true })
+	for _, derived := range []*Heap{mapped, selected} {
+		if actualValue := derived.Arity(); actualValue != 4 {
+			t.Errorf("Got %v expected %v", actualValue, 4)
+		}
+		if actualValue, expectedValue := fmt.Sprint(derived.Drain()), "[1 2 3 4 5 6]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestHeapAnyAllFind(t *testing.T) {
+	heap := newEnumerableHeap()
+	positive := func(index int, value interface{}) bool { return value.(int) > 0 }
+	even := func(index int, value interface{}) bool { return value.(int)%2 == 0 }
+	large := func(index int, value interface{}) bool { return value.(int) > 3 }
+
+	if actualValue := heap.All(positive); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.All(even); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := heap.Any(even); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.Any(large); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if index, value := heap.Find(even); index != 2 || value != 2 {
+		t.Errorf("Got %v,%v expected %v,%v", index, value, 2, 2)
+	}
+	if index, value := heap.Find(large); index != -1 || value != nil {
+		t.Errorf("Got %v,%v expected %v,%v", index, value, -1, nil)
+	}
+}
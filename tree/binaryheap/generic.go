@@ -0,0 +1,146 @@
+package binaryheap
+
+import (
+	"cmp"
+	"encoding/json"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+// HeapOf is the type-parameterized counterpart of Heap. It wraps a Heap, so both share the same behavior.
+type HeapOf[T any] struct {
+	heap       *Heap
+	comparator util.TypedComparator[T]
+}
+
+// IteratorOf is the type-parameterized counterpart of Iterator.
+type IteratorOf[T any] struct {
+	*Iterator
+}
+
+// SortedIteratorOf is the type-parameterized counterpart of SortedIterator.
+type SortedIteratorOf[T any] struct {
+	*SortedIterator
+}
+
+func NewOf[T any](comparator util.TypedComparator[T]) *HeapOf[T] {
+	return &HeapOf[T]{heap: NewWith(comparator.Untyped()), comparator: comparator}
+}
+
+// NewOfWithArity is the type-parameterized counterpart of NewWithArity.
+func NewOfWithArity[T any](d int, comparator util.TypedComparator[T]) *HeapOf[T] {
+	return &HeapOf[T]{heap: NewWithArity(d, comparator.Untyped()), comparator: comparator}
+}
+
+// NewOrdered returns a min-heap ordered by the natural order of T.
+func NewOrdered[T cmp.Ordered]() *HeapOf[T] {
+	return NewOf[T](util.OrderedComparator[T])
+}
+
+// Comparator returns the comparator the heap was created with.
+func (heap *HeapOf[T]) Comparator() util.TypedComparator[T] {
+	return heap.comparator
+}
+
+func (heap *HeapOf[T]) Push(values ...T) {
+	untyped := make([]interface{}, len(values))
+	for i, value := range values {
+		untyped[i] = value
+	}
+	heap.heap.Push(untyped...)
+}
+
+func (heap *HeapOf[T]) Pop() (T, bool) {
+	value, ok := heap.heap.Pop()
+	v, _ := value.(T)
+	return v, ok
+}
+
+func (heap *HeapOf[T]) Peek() (T, bool) {
+	value, ok := heap.heap.Peek()
+	v, _ := value.(T)
+	return v, ok
+}
+
+func (heap *HeapOf[T]) Drain() []T {
+	return typed[T](heap.heap.Drain())
+}
+
+func (heap *HeapOf[T]) Empty() bool {
+	return heap.heap.Empty()
+}
+
+func (heap *HeapOf[T]) Size() int {
+	return heap.heap.Size()
+}
+
+func (heap *HeapOf[T]) Clear() {
+	heap.heap.Clear()
+}
+
+func (heap *HeapOf[T]) Values() []T {
+	return typed[T](heap.heap.Values())
+}
+
+func (heap *HeapOf[T]) String() string {
+	return heap.heap.String()
+}
+
+func (heap *HeapOf[T]) Iterator() IteratorOf[T] {
+	it := heap.heap.Iterator()
+	return IteratorOf[T]{&it}
+}
+
+func (heap *HeapOf[T]) SortedIterator() SortedIteratorOf[T] {
+	it := heap.heap.SortedIterator()
+	return SortedIteratorOf[T]{&it}
+}
+
+// ToJSON produces the same output as Heap.ToJSON.
+func (heap *HeapOf[T]) ToJSON() ([]byte, error) {
+	return heap.heap.ToJSON()
+}
+
+// FromJSON decodes values directly into T. A zero value HeapOf returns util.ErrNotConstructed.
+func (heap *HeapOf[T]) FromJSON(data []byte) error {
+	if heap.heap == nil {
+		return util.ErrNotConstructed
+	}
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	heap.heap.Clear()
+	heap.Push(values...)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, it is equivalent to ToJSON.
+func (heap *HeapOf[T]) MarshalJSON() ([]byte, error) {
+	return heap.ToJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it is equivalent to FromJSON.
+func (heap *HeapOf[T]) UnmarshalJSON(data []byte) error {
+	return heap.FromJSON(data)
+}
+
+// Value returns the current value, the zero value of T if the iterator is not on an element.
+func (it IteratorOf[T]) Value() T {
+	value, _ := it.Iterator.Value().(T)
+	return value
+}
+
+// Value returns the current value, the zero value of T if the iterator is not on an element.
+func (it SortedIteratorOf[T]) Value() T {
+	value, _ := it.SortedIterator.Value().(T)
+	return value
+}
+
+func typed[T any](values []interface{}) []T {
+	result := make([]T, len(values))
+	for i, value := range values {
+		result[i], _ = value.(T)
+	}
+	return result
+}
@@ -0,0 +1,41 @@
+package binaryheap
+
+import "iter"
+
+// AllSeq is the type-parameterized counterpart of Heap.AllSeq.
+func (heap *HeapOf[T]) AllSeq() iter.Seq2[int, T] {
+	return typedSeq2[T](heap.heap.AllSeq())
+}
+
+// BackwardSeq is the type-parameterized counterpart of Heap.BackwardSeq.
+func (heap *HeapOf[T]) BackwardSeq() iter.Seq2[int, T] {
+	return typedSeq2[T](heap.heap.BackwardSeq())
+}
+
+// KeysSeq is the type-parameterized counterpart of Heap.KeysSeq.
+func (heap *HeapOf[T]) KeysSeq() iter.Seq[int] {
+	return heap.heap.KeysSeq()
+}
+
+// ValuesSeq is the type-parameterized counterpart of Heap.ValuesSeq.
+func (heap *HeapOf[T]) ValuesSeq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, value := range heap.heap.AllSeq() {
+			v, _ := value.(T)
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func typedSeq2[T any](seq iter.Seq2[int, interface{}]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for index, value := range seq {
+			v, _ := value.(T)
+			if !yield(index, v) {
+				return
+			}
+		}
+	}
+}
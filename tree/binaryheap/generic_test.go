@@ -0,0 +1,103 @@
+package binaryheap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func TestHeapOfPushAndPop(t *testing.T) {
+	heap := NewOrdered[int]()
+
+	if actualValue, ok := heap.Pop(); actualValue != 0 || ok {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, ok, 0, false)
+	}
+	heap.Push(3, 2, 1)
+	heap.Push(5, 4)
+	if actualValue := heap.Size(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if actualValue, ok := heap.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, ok, 1, true)
+	}
+	for i := 1; i <= 2; i++ {
+		if actualValue, ok := heap.Pop(); actualValue != i || !ok {
+			t.Errorf("Got %v,%v expected %v,%v", actualValue, ok, i, true)
+		}
+	}
+	if actualValue, expectedValue := fmt.Sprint(heap.Drain()), "[3 4 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.Comparator()(1, 2); actualValue >= 0 {
+		t.Errorf("Got %v expected %v", actualValue, "< 0")
+	}
+}
+
+func TestHeapOfIterators(t *testing.T) {
+	heap := NewOf[string](func(a, b string) int { return len(b) - len(a) })
+	heap.Push("a", "aaa", "aa")
+
+	it := heap.Iterator()
+	count := 0
+	for it.Next() {
+		count++
+		if actualValue := it.Value(); actualValue != heap.Values()[it.Index()] {
+			t.Errorf("Got %v expected %v", actualValue, heap.Values()[it.Index()])
+		}
+	}
+	if count != 3 {
+		t.Errorf("Got %v expected %v", count, 3)
+	}
+
+	sorted := heap.SortedIterator()
+	values := ""
+	for sorted.Next() {
+		values += sorted.Value() + " "
+	}
+	if values != "aaa aa a " {
+		t.Errorf("Got %v expected %v", values, "aaa aa a ")
+	}
+	if actualValue := heap.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+}
+
+func TestHeapOfSerialization(t *testing.T) {
+	heap := NewOrdered[float64]()
+	heap.Push(1.5, 0.5, 2.5)
+
+	json, err := heap.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	restored := NewOrdered[float64]()
+	if err := restored.FromJSON(json); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(restored.Drain()), "[0.5 1.5 2.5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := new(HeapOf[float64]).FromJSON(json); err != util.ErrNotConstructed {
+		t.Errorf("Got %v expected %v", err, util.ErrNotConstructed)
+	}
+}
+
+func TestHeapOfSeq(t *testing.T) {
+	heap := NewOrdered[string]()
+	heap.Push("c", "a", "b")
+
+	result := ""
+	for index, value := range heap.AllSeq() {
+		result += fmt.Sprint(index) + value
+	}
+	for value := range heap.ValuesSeq() {
+		result += value
+	}
+	if expectedValue := "0a1c2bacb"; result != expectedValue {
+		t.Errorf("Got %v expected %v", result, expectedValue)
+	}
+}
@@ -0,0 +1,182 @@
+package binaryheap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+// IndexedHeap is a binary heap that hands out a Handle for every pushed value,
+// so that the value can later be updated or removed in O(log n).
+// The zero value with a Comparator is a heap of DefaultArity.
+type IndexedHeap struct {
+	items      []*Handle
+	arity      int
+	Comparator util.Comparator
+}
+
+// Handle refers to a value pushed into an IndexedHeap.
+type Handle struct {
+	value interface{}
+	index int // 在heap.items中的位置，-1表示已经不在堆中
+	heap  *IndexedHeap
+}
+
+// Value returns the current value of the handle.
+func (h *Handle) Value() interface{} {
+	return h.value
+}
+
+func NewIndexedWith(comparator util.Comparator) *IndexedHeap {
+	return NewIndexedWithArity(DefaultArity, comparator)
+}
+
+// NewIndexedWithArity returns a d-ary indexed heap, see NewWithArity. It panics if d is less than 2.
+func NewIndexedWithArity(d int, comparator util.Comparator) *IndexedHeap {
+	if d < 2 {
+		panic("Invalid arity, should be at least 2")
+	}
+	return &IndexedHeap{arity: d, Comparator: comparator}
+}
+
+func NewIndexedWithIntComparator() *IndexedHeap {
+	return NewIndexedWith(util.IntComparator)
+}
+
+func NewIndexedWithStringComparator() *IndexedHeap {
+	return NewIndexedWith(util.StringComparator)
+}
+
+// Arity returns the number of children of every node.
+func (heap *IndexedHeap) Arity() int {
+	if heap.arity == 0 {
+		// 零值没有经过构造函数
+		return DefaultArity
+	}
+	return heap.arity
+}
+
+func (heap *IndexedHeap) Push(value interface{}) *Handle {
+	handle := &Handle{value: value, index: len(heap.items), heap: heap}
+	heap.items = append(heap.items, handle)
+	heap.sifter().up(handle.index)
+	return handle
+}
+
+func (heap *IndexedHeap) Pop() (interface{}, bool) {
+	if len(heap.items) == 0 {
+		return nil, false
+	}
+	handle := heap.items[0]
+	heap.Remove(handle)
+	return handle.value, true
+}
+
+func (heap *IndexedHeap) Peek() (interface{}, bool) {
+	if len(heap.items) == 0 {
+		return nil, false
+	}
+	return heap.items[0].value, true
+}
+
+// PeekHandle returns the handle of the top value.
+func (heap *IndexedHeap) PeekHandle() (*Handle, bool) {
+	if len(heap.items) == 0 {
+		return nil, false
+	}
+	return heap.items[0], true
+}
+
+// Contains reports whether handle was pushed into this heap and has not been popped or removed since.
+func (heap *IndexedHeap) Contains(handle *Handle) bool {
+	return handle != nil && handle.heap == heap && handle.index >= 0
+}
+
+// Update replaces the value of handle and restores the heap order in O(log n).
+// It returns false if the heap does not contain handle.
+func (heap *IndexedHeap) Update(handle *Handle, value interface{}) bool {
+	if !heap.Contains(handle) {
+		return false
+	}
+	handle.value = value
+	heap.fix(handle.index)
+	return true
+}
+
+// Remove deletes the value of handle from the heap in O(log n).
+// It returns false if the heap does not contain handle.
+func (heap *IndexedHeap) Remove(handle *Handle) bool {
+	if !heap.Contains(handle) {
+		return false
+	}
+	// 与最后一个元素交换，删除最后一个元素，再从交换的位置调整堆
+	index, lastIndex := handle.index, len(heap.items)-1
+	heap.swap(index, lastIndex)
+	heap.items[lastIndex] = nil
+	heap.items = heap.items[:lastIndex]
+	handle.index = -1
+	if index < lastIndex {
+		heap.fix(index)
+	}
+	return true
+}
+
+func (heap *IndexedHeap) Empty() bool {
+	return len(heap.items) == 0
+}
+
+func (heap *IndexedHeap) Size() int {
+	return len(heap.items)
+}
+
+func (heap *IndexedHeap) Clear() {
+	for _, handle := range heap.items {
+		handle.index = -1
+	}
+	heap.items = nil
+}
+
+func (heap *IndexedHeap) Values() []interface{} {
+	values := make([]interface{}, len(heap.items))
+	for i, handle := range heap.items {
+		values[i] = handle.value
+	}
+	return values
+}
+
+func (heap *IndexedHeap) String() string {
+	str := "IndexedHeap\n"
+	values := []string{}
+	for _, handle := range heap.items {
+		values = append(values, fmt.Sprintf("%v", handle.value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// fix在index处的值变化后调整堆，值变小向上，值变大向下
+func (heap *IndexedHeap) fix(index int) {
+	s := heap.sifter()
+	if s.up(index) == index {
+		s.down(index)
+	}
+}
+
+// swap交换两个位置的handle并更新它们记录的位置
+func (heap *IndexedHeap) swap(i, j int) {
+	heap.items[i], heap.items[j] = heap.items[j], heap.items[i]
+	heap.items[i].index = i
+	heap.items[j].index = j
+}
+
+func (heap *IndexedHeap) sifter() sifter {
+	return sifter{
+		arity: heap.Arity(),
+		size:  len(heap.items),
+		compare: func(i, j int) int {
+			return heap.Comparator(heap.items[i].value, heap.items[j].value)
+		},
+		swap: heap.swap,
+	}
+}
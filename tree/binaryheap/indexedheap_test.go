@@ -0,0 +1,222 @@
+package binaryheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func TestIndexedHeapPushAndPop(t *testing.T) {
+	heap := NewIndexedWithIntComparator()
+
+	if actualValue, ok := heap.Pop(); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	heap.Push(3)
+	heap.Push(2)
+	handle := heap.Push(1)
+
+	if actualValue := heap.Values(); actualValue[0].(int) != 1 || actualValue[1].(int) != 3 || actualValue[2].(int) != 2 {
+		t.Errorf("Got %v expected %v", actualValue, "[1,3,2]")
+	}
+	if actualValue, ok := heap.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if top, ok := heap.PeekHandle(); top != handle || !ok {
+		t.Errorf("Got %v expected %v", top, handle)
+	}
+	for _, expectedValue := range []int{1, 2, 3} {
+		if actualValue, ok := heap.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue := heap.Contains(handle); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestIndexedHeapUpdate(t *testing.T) {
+	heap := NewIndexedWithIntComparator()
+	handles := []*Handle{}
+	for _, value := range []int{10, 20, 30, 40, 50} {
+		handles = append(handles, heap.Push(value))
+	}
+
+	// decrease key
+	if actualValue := heap.Update(handles[3], 5); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue, _ := heap.Peek(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	// increase key
+	heap.Update(handles[3], 60)
+	heap.Update(handles[0], 35)
+	expected := []int{20, 30, 35, 50, 60}
+	for _, expectedValue := range expected {
+		if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue := heap.Update(handles[0], 1); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := handles[0].Value(); actualValue != 35 {
+		t.Errorf("Got %v expected %v", actualValue, 35)
+	}
+}
+
+func TestIndexedHeapRemove(t *testing.T) {
+	heap := NewIndexedWithIntComparator()
+	other := NewIndexedWithIntComparator()
+	handles := []*Handle{}
+	for _, value := range []int{5, 3, 8, 1, 9, 7} {
+		handles = append(handles, heap.Push(value))
+	}
+	foreign := other.Push(1)
+
+	if actualValue := heap.Contains(foreign); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := heap.Remove(foreign); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := heap.Remove(handles[3]); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.Remove(handles[3]); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	heap.Remove(handles[2])
+	for _, expectedValue := range []int{3, 5, 7, 9} {
+		if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	handle := heap.Push(1)
+	heap.Clear()
+	if actualValue := heap.Contains(handle); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestIndexedHeapRandom(t *testing.T) {
+	heap := NewIndexedWithIntComparator()
+	r := rand.New(rand.NewSource(3))
+	handles := []*Handle{}
+	for i := 0; i < 2000; i++ {
+		handles = append(handles, heap.Push(r.Intn(1000)))
+	}
+	for i := 0; i < 1000; i++ {
+		handle := handles[r.Intn(len(handles))]
+		if r.Intn(2) == 0 {
+			heap.Update(handle, r.Intn(1000))
+		} else {
+			heap.Remove(handle)
+		}
+	}
+	expected := []int{}
+	for _, handle := range handles {
+		if heap.Contains(handle) {
+			expected = append(expected, handle.Value().(int))
+		}
+	}
+	sort.Ints(expected)
+	if actualValue, expectedValue := heap.Size(), len(expected); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for _, expectedValue := range expected {
+		if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			break
+		}
+	}
+}
+
+func TestIndexedHeapArity(t *testing.T) {
+	for _, d := range []int{2, 3, 4} {
+		heap := NewIndexedWithArity(d, util.IntComparator)
+		r := rand.New(rand.NewSource(int64(d)))
+		handles := []*Handle{}
+		for i := 0; i < 500; i++ {
+			handles = append(handles, heap.Push(r.Intn(1000)))
+		}
+		for i := 0; i < 500; i++ {
+			handle := handles[r.Intn(len(handles))]
+			if r.Intn(2) == 0 {
+				heap.Update(handle, r.Intn(1000))
+			} else {
+				heap.Remove(handle)
+			}
+		}
+		expected := []int{}
+		for _, handle := range handles {
+			if heap.Contains(handle) {
+				expected = append(expected, handle.Value().(int))
+			}
+		}
+		sort.Ints(expected)
+		for _, expectedValue := range expected {
+			if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+				break
+			}
+		}
+	}
+}
+
+func TestIndexedHeapZeroValue(t *testing.T) {
+	for _, heap := range []*IndexedHeap{{Comparator: util.IntComparator}, NewIndexedWithIntComparator()} {
+		if actualValue := heap.Arity(); actualValue != DefaultArity {
+			t.Errorf("Got %v expected %v", actualValue, DefaultArity)
+		}
+		handles := []*Handle{}
+		for _, value := range []int{5, 3, 8, 1, 9, 7} {
+			handles = append(handles, heap.Push(value))
+		}
+		heap.Update(handles[2], 0)
+		heap.Remove(handles[4])
+		for _, expectedValue := range []int{0, 1, 3, 5, 7} {
+			if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+}
+
+// benchmarkDecrease lowers random values, the workload of Dijkstra's algorithm.
+func benchmarkDecrease(b *testing.B, d int, size int) {
+	b.StopTimer()
+	heap := NewIndexedWithArity(d, util.IntComparator)
+	handles := make([]*Handle, size)
+	for n := 0; n < size; n++ {
+		handles[n] = heap.Push(n + size*b.N)
+	}
+	r := rand.New(rand.NewSource(1))
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			handle := handles[r.Intn(size)]
+			heap.Update(handle, handle.Value().(int)-r.Intn(size))
+		}
+	}
+}
+
+func BenchmarkIndexedHeapArity2Decrease10000(b *testing.B) {
+	benchmarkDecrease(b, 2, 10000)
+}
+
+func BenchmarkIndexedHeapArity4Decrease10000(b *testing.B) {
+	benchmarkDecrease(b, 4, 10000)
+}
+
+func BenchmarkIndexedHeapArity8Decrease10000(b *testing.B) {
+	benchmarkDecrease(b, 8, 10000)
+}
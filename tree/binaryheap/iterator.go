@@ -1,5 +1,11 @@
 package binaryheap
 
+import "github.com/morganxf/algorithm/container"
+
+func assertSortedIteratorImplementation() {
+	var _ container.IteratorWithIndex = (*SortedIterator)(nil)
+}
+
 type Iterator struct {
 	heap  *Heap
 	index int
@@ -49,3 +55,66 @@ func (it *Iterator) Last() bool {
 	it.End()
 	return it.Prev()
 }
+
+// SortedIterator yields the values of a heap in comparator order without modifying the heap.
+// The first k values cost O(k log k). Modifying the heap invalidates the iterator.
+type SortedIterator struct {
+	heap *Heap
+	// 候选位置组成的辅助堆，按照位置上的值排序
+	candidates *Heap
+	index      int
+	value      interface{}
+}
+
+func (heap *Heap) SortedIterator() SortedIterator {
+	it := SortedIterator{heap: heap}
+	it.candidates = NewWith(func(a, b interface{}) int {
+		aValue, _ := heap.list.Get(a.(int))
+		bValue, _ := heap.list.Get(b.(int))
+		return heap.Comparator(aValue, bValue)
+	})
+	it.Begin()
+	return it
+}
+
+func (it *SortedIterator) Next() bool {
+	if it.index >= it.heap.Size() {
+		return false
+	}
+	it.index++
+	position, ok := it.candidates.Pop()
+	if !ok {
+		it.value = nil
+		return false
+	}
+	// 堆中位置的孩子一定不小于它，弹出后孩子成为新的候选
+	firstIndex := it.heap.firstChildIndex(position.(int))
+	for childIndex := firstIndex; childIndex < firstIndex+it.heap.arity && childIndex < it.heap.Size(); childIndex++ {
+		it.candidates.Push(childIndex)
+	}
+	it.value, _ = it.heap.list.Get(position.(int))
+	return true
+}
+
+func (it *SortedIterator) Value() interface{} {
+	return it.value
+}
+
+// Index returns the rank of the current value in comparator order.
+func (it *SortedIterator) Index() int {
+	return it.index
+}
+
+func (it *SortedIterator) Begin() {
+	it.candidates.Clear()
+	if !it.heap.Empty() {
+		it.candidates.Push(0)
+	}
+	it.index = -1
+	it.value = nil
+}
+
+func (it *SortedIterator) First() bool {
+	it.Begin()
+	return it.Next()
+}
@@ -0,0 +1,49 @@
+package binaryheap
+
+import "iter"
+
+// AllSeq returns an iterator over the index/value pairs of the heap in storage order, for use with range.
+func (heap *Heap) AllSeq() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for index := 0; index < heap.list.Size(); index++ {
+			value, _ := heap.list.Get(index)
+			if !yield(index, value) {
+				return
+			}
+		}
+	}
+}
+
+// BackwardSeq returns an iterator over the index/value pairs of the heap in reverse storage order.
+func (heap *Heap) BackwardSeq() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for index := heap.list.Size() - 1; index >= 0; index-- {
+			value, _ := heap.list.Get(index)
+			if !yield(index, value) {
+				return
+			}
+		}
+	}
+}
+
+// KeysSeq returns an iterator over the indexes of the heap.
+func (heap *Heap) KeysSeq() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for index := 0; index < heap.list.Size(); index++ {
+			if !yield(index) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesSeq returns an iterator over the values of the heap in storage order.
+func (heap *Heap) ValuesSeq() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, value := range heap.AllSeq() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package binaryheap
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHeapAllSeq(t *testing.T) {
+	heap := NewWithIntComparator()
+	heap.Push(3, 1, 2) // [1,3,2]
+
+	indexes, values := []int{}, []interface{}{}
+	for index, value := range heap.AllSeq() {
+		indexes = append(indexes, index)
+		values = append(values, value)
+	}
+	if actualValue, expectedValue := fmt.Sprint(indexes, values), "[0 1 2] [1 3 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	values = values[:0]
+	for _, value := range heap.BackwardSeq() {
+		values = append(values, value)
+	}
+	if actualValue, expectedValue := fmt.Sprint(values), "[2 3 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	indexes = indexes[:0]
+	for index := range heap.KeysSeq() {
+		indexes = append(indexes, index)
+	}
+	if actualValue, expectedValue := fmt.Sprint(indexes), "[0 1 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	values = values[:0]
+	for value := range heap.ValuesSeq() {
+		if value == 2 {
+			break
+		}
+		values = append(values, value)
+	}
+	if actualValue, expectedValue := fmt.Sprint(values), "[1 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
@@ -0,0 +1,288 @@
+package binomialheap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/tree/internal/heapowner"
+	"github.com/morganxf/algorithm/util"
+)
+
+func assertHeapImplementation() {
+	var _ container.Container = (*Heap)(nil)
+}
+
+// Heap is a binomial heap, a list of binomial trees with distinct degrees.
+// Push, Pop, Meld and DecreaseKey cost O(log n).
+type Heap struct {
+	head       *node // 根链表, 按照度数递增
+	size       int
+	owner      *heapowner.Owner // 第一次PushHandle或Meld时创建
+	Comparator util.Comparator
+}
+
+// Handle refers to a value pushed into a Heap.
+type Handle struct {
+	value interface{}
+	node  *node
+	owner *heapowner.Owner // nil表示已经不在堆中
+}
+
+// 值在节点之间交换时handle跟随值移动, 所以handle与节点分开
+type node struct {
+	handle  *Handle
+	parent  *node
+	child   *node // 度数最大的孩子
+	sibling *node
+	degree  int
+}
+
+// Value returns the current value of the handle.
+func (h *Handle) Value() interface{} {
+	return h.value
+}
+
+func NewWith(comparator util.Comparator) *Heap {
+	return &Heap{Comparator: comparator}
+}
+
+func NewWithIntComparator() *Heap {
+	return NewWith(util.IntComparator)
+}
+
+func NewWithStringComparator() *Heap {
+	return NewWith(util.StringComparator)
+}
+
+func (heap *Heap) Push(values ...interface{}) {
+	for _, value := range values {
+		heap.PushHandle(value)
+	}
+}
+
+// PushHandle adds value to the heap and returns its handle for DecreaseKey and Remove.
+func (heap *Heap) PushHandle(value interface{}) *Handle {
+	handle := &Handle{value: value, owner: heap.init()}
+	handle.node = &node{handle: handle}
+	heap.head = heap.union(heap.head, handle.node)
+	heap.size++
+	return handle
+}
+
+func (heap *Heap) Pop() (interface{}, bool) {
+	handle, ok := heap.PeekHandle()
+	if !ok {
+		return nil, false
+	}
+	if !heap.Remove(handle) {
+		return nil, false
+	}
+	return handle.value, true
+}
+
+func (heap *Heap) Peek() (interface{}, bool) {
+	handle, ok := heap.PeekHandle()
+	if !ok {
+		return nil, false
+	}
+	return handle.value, true
+}
+
+// PeekHandle returns the handle of the top value.
+func (heap *Heap) PeekHandle() (*Handle, bool) {
+	if heap.head == nil {
+		return nil, false
+	}
+	top := heap.head
+	for cur := heap.head.sibling; cur != nil; cur = cur.sibling {
+		if heap.less(cur, top) {
+			top = cur
+		}
+	}
+	return top.handle, true
+}
+
+// Contains reports whether handle is in this heap, either pushed into it or melded into it from another heap.
+func (heap *Heap) Contains(handle *Handle) bool {
+	return handle != nil && heap.owner.Owns(handle.owner)
+}
+
+// DecreaseKey replaces the value of handle with a value that is not greater than it, in O(log n).
+// It returns false if the heap does not contain handle and panics if value is greater than the current value.
+func (heap *Heap) DecreaseKey(handle *Handle, value interface{}) bool {
+	if !heap.Contains(handle) {
+		return false
+	}
+	if heap.Comparator(value, handle.value) > 0 {
+		panic("Invalid key, greater than the current value")
+	}
+	handle.value = value
+	for cur := handle.node; cur.parent != nil && heap.less(cur, cur.parent); cur = cur.parent {
+		heap.swap(cur, cur.parent)
+	}
+	return true
+}
+
+// Remove deletes the value of handle from the heap in O(log n).
+// It returns false if the heap does not contain handle.
+func (heap *Heap) Remove(handle *Handle) bool {
+	if !heap.Contains(handle) {
+		return false
+	}
+	// 把handle一直交换到根, 相当于把值减小到负无穷
+	root := handle.node
+	for ; root.parent != nil; root = root.parent {
+		heap.swap(root, root.parent)
+	}
+	// 从根链表中摘下该树, 它的孩子反转后是一个度数递增的根链表
+	if heap.head == root {
+		heap.head = root.sibling
+	} else {
+		prev := heap.head
+		for prev.sibling != root {
+			prev = prev.sibling
+		}
+		prev.sibling = root.sibling
+	}
+	var children *node
+	for child := root.child; child != nil; {
+		next := child.sibling
+		child.parent = nil
+		child.sibling = children
+		children = child
+		child = next
+	}
+	heap.head = heap.union(heap.head, children)
+	handle.node, handle.owner = nil, nil
+	heap.size--
+	return true
+}
+
+// Meld moves all the values of other into the heap in O(log n), leaving other empty.
+// The handles of other stay valid and belong to the heap afterwards.
+func (heap *Heap) Meld(other *Heap) {
+	if other == heap || other.head == nil {
+		return
+	}
+	heap.head = heap.union(heap.head, other.head)
+	heap.size += other.size
+	other.owner.Meld(heap.init())
+	other.head, other.size, other.owner = nil, 0, nil
+}
+
+func (heap *Heap) Empty() bool {
+	return heap.size == 0
+}
+
+func (heap *Heap) Size() int {
+	return heap.size
+}
+
+// Clear removes all the values, the handles of the heap are no longer contained in it.
+func (heap *Heap) Clear() {
+	heap.head = nil
+	heap.size = 0
+	heap.owner = nil
+}
+
+// init创建零值堆的owner
+func (heap *Heap) init() *heapowner.Owner {
+	if heap.owner == nil {
+		heap.owner = heapowner.New()
+	}
+	return heap.owner
+}
+
+// Values returns the values in no particular order.
+func (heap *Heap) Values() []interface{} {
+	values := make([]interface{}, 0, heap.size)
+	var walk func(n *node)
+	walk = func(n *node) {
+		for ; n != nil; n = n.sibling {
+			values = append(values, n.handle.value)
+			walk(n.child)
+		}
+	}
+	walk(heap.head)
+	return values
+}
+
+func (heap *Heap) String() string {
+	str := "BinomialHeap\n"
+	values := []string{}
+	for _, value := range heap.Values() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+func (heap *Heap) less(a *node, b *node) bool {
+	return heap.Comparator(a.handle.value, b.handle.value) < 0
+}
+
+// swap交换两个节点的值, handle跟随值移动
+func (heap *Heap) swap(a *node, b *node) {
+	a.handle, b.handle = b.handle, a.handle
+	a.handle.node = a
+	b.handle.node = b
+}
+
+// union合并两个根链表, 再把度数相同的树两两链接, 类似二进制加法
+func (heap *Heap) union(a *node, b *node) *node {
+	head := mergeRoots(a, b)
+	if head == nil {
+		return nil
+	}
+	var prev *node
+	cur, next := head, head.sibling
+	for next != nil {
+		switch {
+		case cur.degree != next.degree || (next.sibling != nil && next.sibling.degree == cur.degree):
+			// 度数不同, 或者有三棵度数相同的树时先跳过第一棵
+			prev, cur = cur, next
+		case !heap.less(next, cur):
+			cur.sibling = next.sibling
+			link(next, cur)
+		default:
+			if prev == nil {
+				head = next
+			} else {
+				prev.sibling = next
+			}
+			link(cur, next)
+			cur = next
+		}
+		next = cur.sibling
+	}
+	return head
+}
+
+// mergeRoots按照度数归并两个根链表
+func mergeRoots(a *node, b *node) *node {
+	var head node
+	tail := &head
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			tail.sibling, a = a, a.sibling
+		} else {
+			tail.sibling, b = b, b.sibling
+		}
+		tail = tail.sibling
+	}
+	if a != nil {
+		tail.sibling = a
+	} else {
+		tail.sibling = b
+	}
+	return head.sibling
+}
+
+// link把child链接为parent的第一个孩子, 两者度数相同
+func link(child *node, parent *node) {
+	child.parent = parent
+	child.sibling = parent.child
+	parent.child = child
+	parent.degree++
+}
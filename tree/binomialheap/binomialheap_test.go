@@ -0,0 +1,361 @@
+package binomialheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func TestBinomialHeapPushAndPop(t *testing.T) {
+	heap := NewWithIntComparator()
+
+	if actualValue, ok := heap.Pop(); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue, ok := heap.Peek(); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	heap.Push(3)
+	heap.Push(5, 1, 4)
+	handle := heap.PushHandle(2)
+	assertValidBinomialHeap(t, heap)
+
+	if actualValue, ok := heap.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	for _, expectedValue := range []int{1, 2, 3, 4, 5} {
+		if actualValue, ok := heap.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		assertValidBinomialHeap(t, heap)
+	}
+	if actualValue := heap.Contains(handle); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestBinomialHeapDegrees(t *testing.T) {
+	// 根链表的度数就是size的二进制表示中为1的位
+	heap := NewWithIntComparator()
+	for i := 1; i <= 64; i++ {
+		heap.Push(64 - i)
+		if actualValue, expectedValue := degrees(heap), bits(i); !equalInts(actualValue, expectedValue) {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	for i := 63; i >= 0; i-- {
+		heap.Pop()
+		if actualValue, expectedValue := degrees(heap), bits(i); !equalInts(actualValue, expectedValue) {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	assertValidBinomialHeap(t, heap)
+}
+
+func TestBinomialHeapDecreaseKeyAndRemove(t *testing.T) {
+	heap := NewWithIntComparator()
+	handles := []*Handle{}
+	for _, value := range []int{10, 20, 30, 40, 50, 60, 70, 80} {
+		handles = append(handles, heap.PushHandle(value))
+	}
+	// 8个值组成一棵度数为3的树, 80在最底层
+	if actualValue := degrees(heap); !equalInts(actualValue, []int{3}) {
+		t.Errorf("Got %v expected %v", actualValue, []int{3})
+	}
+	if actualValue := handles[7].node.parent; actualValue == nil {
+		t.Errorf("Got %v expected %v", actualValue, "parent")
+	}
+
+	// 减小的值沿着父亲上浮, handle跟随值移动到根
+	if actualValue := heap.DecreaseKey(handles[7], 5); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := handles[7].node; actualValue != heap.head {
+		t.Errorf("Got %v expected %v", actualValue.handle.value, heap.head.handle.value)
+	}
+	if top, ok := heap.PeekHandle(); top != handles[7] || !ok {
+		t.Errorf("Got %v expected %v", top.value, handles[7].value)
+	}
+	assertValidBinomialHeap(t, heap)
+
+	// 删除把树拆成度数为0到2的三棵树, 剩下7个值
+	if actualValue := heap.Remove(handles[2]); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.Remove(handles[2]); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := degrees(heap); !equalInts(actualValue, []int{0, 1, 2}) {
+		t.Errorf("Got %v expected %v", actualValue, []int{0, 1, 2})
+	}
+	assertValidBinomialHeap(t, heap)
+	for _, expectedValue := range []int{5, 10, 20, 40, 50, 60, 70} {
+		if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue := heap.DecreaseKey(handles[0], 1); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+
+	handle := heap.PushHandle(1)
+	heap.Clear()
+	if actualValue := heap.Contains(handle); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestBinomialHeapDecreaseKeyInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got %v expected %v", r, "panic")
+		}
+	}()
+	heap := NewWithIntComparator()
+	heap.DecreaseKey(heap.PushHandle(1), 2)
+}
+
+func TestBinomialHeapMeld(t *testing.T) {
+	heap := NewWithIntComparator()
+	other := NewWithIntComparator()
+	heap.Push(4, 8, 3)
+	handle := other.PushHandle(6)
+	other.Push(2, 7, 9, 1)
+
+	// 合并像二进制加法: 3 + 5 = 8, 只剩一棵度数为3的树
+	heap.Meld(other)
+	if actualValue := degrees(heap); !equalInts(actualValue, []int{3}) {
+		t.Errorf("Got %v expected %v", actualValue, []int{3})
+	}
+	if actualValue := other.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := other.Contains(handle); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	assertValidBinomialHeap(t, heap)
+
+	heap.Meld(heap)
+	third := NewWithIntComparator()
+	third.Push(5)
+	third.Meld(heap)
+	if actualValue := degrees(third); !equalInts(actualValue, []int{0, 3}) {
+		t.Errorf("Got %v expected %v", actualValue, []int{0, 3})
+	}
+	if actualValue := third.Contains(handle); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	third.DecreaseKey(handle, 0)
+	for _, expectedValue := range []int{0, 1, 2, 3, 4, 5, 7, 8, 9} {
+		if actualValue, _ := third.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestBinomialHeapZeroValue(t *testing.T) {
+	heap := &Heap{Comparator: util.IntComparator}
+	if actualValue := heap.Contains(&Handle{}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	handle := heap.PushHandle(3)
+	heap.Push(1, 2)
+	if actualValue := heap.Contains(handle); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.DecreaseKey(handle, 0); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	other := &Heap{Comparator: util.IntComparator}
+	other.Meld(heap)
+	heap.Meld(&Heap{Comparator: util.IntComparator})
+	if actualValue := other.Contains(handle); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	for _, expectedValue := range []int{0, 1, 2} {
+		if actualValue, ok := other.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue := other.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestBinomialHeapRandom(t *testing.T) {
+	heaps := []*Heap{NewWithIntComparator(), NewWithIntComparator()}
+	r := rand.New(rand.NewSource(3))
+	handles := []*Handle{}
+	for i := 0; i < 2000; i++ {
+		handles = append(handles, heaps[i%2].PushHandle(r.Intn(1000)))
+	}
+	heap := heaps[0]
+	heap.Meld(heaps[1])
+	for i := 0; i < 1500; i++ {
+		if i%100 == 0 {
+			assertValidBinomialHeap(t, heap)
+		}
+		handle := handles[r.Intn(len(handles))]
+		switch r.Intn(3) {
+		case 0:
+			if heap.Contains(handle) {
+				heap.DecreaseKey(handle, handle.Value().(int)-r.Intn(100))
+			}
+		case 1:
+			heap.Remove(handle)
+		default:
+			heap.Pop()
+		}
+	}
+	assertValidBinomialHeap(t, heap)
+	expected := []int{}
+	for _, handle := range handles {
+		if heap.Contains(handle) {
+			expected = append(expected, handle.Value().(int))
+		}
+	}
+	sort.Ints(expected)
+	if actualValue, expectedValue := heap.Size(), len(expected); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for _, expectedValue := range expected {
+		if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			break
+		}
+	}
+}
+
+// degrees返回根链表中各棵树的度数
+func degrees(heap *Heap) []int {
+	result := []int{}
+	for root := heap.head; root != nil; root = root.sibling {
+		result = append(result, root.degree)
+	}
+	return result
+}
+
+// bits返回n的二进制表示中为1的位, 从低到高
+func bits(n int) []int {
+	result := []int{}
+	for bit := 0; n > 0; bit, n = bit+1, n>>1 {
+		if n&1 == 1 {
+			result = append(result, bit)
+		}
+	}
+	return result
+}
+
+func equalInts(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func assertValidBinomialHeap(t *testing.T, heap *Heap) {
+	var check func(n *node) int
+	check = func(n *node) int {
+		if !heap.Contains(n.handle) {
+			t.Errorf("Got handle %v not contained in the heap", n.handle.value)
+		}
+		if n.handle.node != n {
+			t.Errorf("Got handle %v not pointing to its node", n.handle.value)
+		}
+		size, degree := 1, 0
+		// 孩子的度数从degree-1递减到0
+		for child := n.child; child != nil; child = child.sibling {
+			if child.parent != n {
+				t.Errorf("Got parent %v expected %v", child.parent.handle.value, n.handle.value)
+			}
+			if heap.less(child, n) {
+				t.Errorf("Got child %v less than %v", child.handle.value, n.handle.value)
+			}
+			if expectedValue := n.degree - 1 - degree; child.degree != expectedValue {
+				t.Errorf("Got degree %v expected %v", child.degree, expectedValue)
+			}
+			size += check(child)
+			degree++
+		}
+		if size != 1<<n.degree {
+			t.Errorf("Got size %v expected %v", size, 1<<n.degree)
+		}
+		return size
+	}
+	size := 0
+	for root := heap.head; root != nil; root = root.sibling {
+		if root.parent != nil {
+			t.Errorf("Got root %v with a parent", root.handle.value)
+		}
+		if root.sibling != nil && root.sibling.degree <= root.degree {
+			t.Errorf("Got degree %v after %v", root.sibling.degree, root.degree)
+		}
+		size += check(root)
+	}
+	if size != heap.Size() {
+		t.Errorf("Got size %v expected %v", size, heap.Size())
+	}
+}
+
+func benchmarkPush(b *testing.B, heap *Heap, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			heap.Push(n)
+		}
+	}
+}
+
+func benchmarkPop(b *testing.B, heap *Heap, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			heap.Pop()
+		}
+	}
+}
+
+func BenchmarkBinomialHeapPush1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	heap := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkBinomialHeapPush100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	heap := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkBinomialHeapPop1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	heap := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPop(b, heap, size)
+}
+
+func BenchmarkBinomialHeapPop100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	heap := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPop(b, heap, size)
+}
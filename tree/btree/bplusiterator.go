@@ -0,0 +1,118 @@
+package btree
+
+// BPlusIterator walks the linked leaves of a BPlusTree, each step is O(1).
+type BPlusIterator struct {
+	tree     *BPlusTree
+	leaf     *BPlusNode
+	index    int
+	position position
+}
+
+func (t *BPlusTree) Iterator() BPlusIterator {
+	return BPlusIterator{tree: t, position: begin}
+}
+
+func (it *BPlusIterator) Next() bool {
+	switch it.position {
+	case end:
+		return false
+	case begin:
+		it.leaf = it.tree.Left()
+		it.index = 0
+	case between:
+		it.index++
+		if it.index >= len(it.leaf.Entries) {
+			it.leaf = it.leaf.Next
+			it.index = 0
+		}
+	}
+	if it.leaf == nil {
+		it.End()
+		return false
+	}
+	it.position = between
+	return true
+}
+
+func (it *BPlusIterator) Prev() bool {
+	switch it.position {
+	case begin:
+		return false
+	case end:
+		it.leaf = it.tree.Right()
+		if it.leaf != nil {
+			it.index = len(it.leaf.Entries) - 1
+		}
+	case between:
+		it.index--
+		if it.index < 0 {
+			it.leaf = it.leaf.Prev
+			if it.leaf != nil {
+				it.index = len(it.leaf.Entries) - 1
+			}
+		}
+	}
+	if it.leaf == nil {
+		it.Begin()
+		return false
+	}
+	it.position = between
+	return true
+}
+
+// Seek moves the iterator to the first element whose key is greater than or equal to key,
+// with a single descent from the root. It returns false and moves past the end if there is none.
+func (it *BPlusIterator) Seek(key interface{}) bool {
+	if it.tree.Empty() {
+		it.End()
+		return false
+	}
+	leaf := it.tree.findLeaf(key)
+	index, _ := it.tree.searchEntries(leaf, key)
+	if index >= len(leaf.Entries) {
+		// key大于该叶子中所有的entry，从下一个叶子开始
+		leaf, index = leaf.Next, 0
+	}
+	if leaf == nil {
+		it.End()
+		return false
+	}
+	it.leaf, it.index, it.position = leaf, index, between
+	return true
+}
+
+func (it *BPlusIterator) Key() interface{} {
+	if it.leaf == nil {
+		return nil
+	}
+	return it.leaf.Entries[it.index].Key
+}
+
+func (it *BPlusIterator) Value() interface{} {
+	if it.leaf == nil {
+		return nil
+	}
+	return it.leaf.Entries[it.index].Value
+}
+
+func (it *BPlusIterator) Begin() {
+	it.leaf = nil
+	it.index = 0
+	it.position = begin
+}
+
+func (it *BPlusIterator) End() {
+	it.leaf = nil
+	it.index = 0
+	it.position = end
+}
+
+func (it *BPlusIterator) First() bool {
+	it.Begin()
+	return it.Next()
+}
+
+func (it *BPlusIterator) Last() bool {
+	it.End()
+	return it.Prev()
+}
@@ -0,0 +1,92 @@
+package btree
+
+import (
+	"iter"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+// AllSeq returns an iterator over the key/value pairs of the tree in key order, for use with range.
+func (t *BPlusTree) AllSeq() iter.Seq2[interface{}, interface{}] {
+	return t.RangeSeq(nil, nil, util.IncludeBoth)
+}
+
+// BackwardSeq returns an iterator over the key/value pairs of the tree in reverse key order.
+func (t *BPlusTree) BackwardSeq() iter.Seq2[interface{}, interface{}] {
+	return t.RangeBackwardSeq(nil, nil, util.IncludeBoth)
+}
+
+// KeysSeq returns an iterator over the keys of the tree in order.
+func (t *BPlusTree) KeysSeq() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for key := range t.AllSeq() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesSeq returns an iterator over the values of the tree in key order.
+func (t *BPlusTree) ValuesSeq() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, value := range t.AllSeq() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// RangeSeq returns an iterator over the key/value pairs whose keys are between from and to, in key order.
+// A nil from or to, or util.UnboundedFrom or util.UnboundedTo, leaves that end of the range unbounded.
+// It descends the tree once and then follows the leaf links.
+func (t *BPlusTree) RangeSeq(from interface{}, to interface{}, inclusion util.Inclusion) iter.Seq2[interface{}, interface{}] {
+	from, to = inclusion.Bounds(from, to)
+	return func(yield func(interface{}, interface{}) bool) {
+		it := t.Iterator()
+		var ok bool
+		if from == nil {
+			ok = it.Next()
+		} else if ok = it.Seek(from); ok && inclusion&util.IncludeFrom == 0 && t.Comparator(it.Key(), from) == 0 {
+			ok = it.Next()
+		}
+		for ; ok; ok = it.Next() {
+			if to != nil {
+				if cmp := t.Comparator(it.Key(), to); cmp > 0 || (cmp == 0 && inclusion&util.IncludeTo == 0) {
+					return
+				}
+			}
+			if !yield(it.Key(), it.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// RangeBackwardSeq returns an iterator over the key/value pairs whose keys are between from and to, in reverse key order.
+func (t *BPlusTree) RangeBackwardSeq(from interface{}, to interface{}, inclusion util.Inclusion) iter.Seq2[interface{}, interface{}] {
+	from, to = inclusion.Bounds(from, to)
+	return func(yield func(interface{}, interface{}) bool) {
+		it := t.Iterator()
+		var ok bool
+		if to == nil {
+			ok = it.Last()
+		} else if ok = it.Seek(to); !ok {
+			// 所有的key都小于to, 从最后一个entry开始
+			ok = it.Last()
+		} else if cmp := t.Comparator(it.Key(), to); cmp > 0 || (cmp == 0 && inclusion&util.IncludeTo == 0) {
+			ok = it.Prev()
+		}
+		for ; ok; ok = it.Prev() {
+			if from != nil {
+				if cmp := t.Comparator(it.Key(), from); cmp < 0 || (cmp == 0 && inclusion&util.IncludeFrom == 0) {
+					return
+				}
+			}
+			if !yield(it.Key(), it.Value()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,476 @@
+package btree
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+// BPlusTree is a B+ tree: entries are only stored in leaves, internal nodes hold separator keys,
+// and the leaves are linked in both directions so that sequential scans do not climb the tree.
+type BPlusTree struct {
+	Root       *BPlusNode
+	Comparator util.Comparator
+	KeyDecoder util.KeyDecoder // 可选, FromJSON用于还原自定义类型的key
+	size       int
+	m          int
+}
+
+// BPlusNode is either an internal node (Keys and Children) or a leaf (Entries, Prev and Next).
+// Keys[i] separates Children[i] and Children[i+1]: keys in Children[i] < Keys[i] <= keys in Children[i+1].
+type BPlusNode struct {
+	Parent   *BPlusNode
+	Keys     []interface{}
+	Children []*BPlusNode
+	Entries  []*Entry
+	Prev     *BPlusNode
+	Next     *BPlusNode
+}
+
+func NewBPlusWith(order int, comparator util.Comparator) *BPlusTree {
+	if order < 3 {
+		panic("Invalid order, should be at least 3")
+	}
+	return &BPlusTree{m: order, Comparator: comparator}
+}
+
+func NewBPlusWithIntComparator(order int) *BPlusTree {
+	return NewBPlusWith(order, util.IntComparator)
+}
+
+func NewBPlusWithStringComparator(order int) *BPlusTree {
+	return NewBPlusWith(order, util.StringComparator)
+}
+
+func (n *BPlusNode) isLeaf() bool {
+	return len(n.Children) == 0
+}
+
+func (n *BPlusNode) height() int {
+	height := 0
+	for n != nil {
+		height++
+		if n.isLeaf() {
+			break
+		}
+		n = n.Children[0]
+	}
+	return height
+}
+
+func (t *BPlusTree) Put(key interface{}, value interface{}) {
+	entry := &Entry{Key: key, Value: value}
+	if t.Root == nil {
+		t.Root = &BPlusNode{Entries: []*Entry{entry}}
+		t.size++
+		return
+	}
+	leaf := t.findLeaf(key)
+	index, found := t.searchEntries(leaf, key)
+	if found {
+		leaf.Entries[index] = entry
+		return
+	}
+	leaf.Entries = append(leaf.Entries, nil)
+	copy(leaf.Entries[index+1:], leaf.Entries[index:])
+	leaf.Entries[index] = entry
+	t.size++
+	t.splitLeaf(leaf)
+}
+
+func (t *BPlusTree) Get(key interface{}) (value interface{}, found bool) {
+	if t.Root == nil {
+		return nil, false
+	}
+	leaf := t.findLeaf(key)
+	if index, found := t.searchEntries(leaf, key); found {
+		return leaf.Entries[index].Value, true
+	}
+	return nil, false
+}
+
+func (t *BPlusTree) Remove(key interface{}) {
+	if t.Root == nil {
+		return
+	}
+	leaf := t.findLeaf(key)
+	index, found := t.searchEntries(leaf, key)
+	if !found {
+		return
+	}
+	copy(leaf.Entries[index:], leaf.Entries[index+1:])
+	leaf.Entries[len(leaf.Entries)-1] = nil
+	leaf.Entries = leaf.Entries[:len(leaf.Entries)-1]
+	t.size--
+	t.rebalanceLeaf(leaf)
+}
+
+func (t *BPlusTree) Empty() bool {
+	return t.size == 0
+}
+
+func (t *BPlusTree) Size() int {
+	return t.size
+}
+
+func (t *BPlusTree) Keys() []interface{} {
+	keys := make([]interface{}, t.size)
+	it := t.Iterator()
+	for i := 0; it.Next(); i++ {
+		keys[i] = it.Key()
+	}
+	return keys
+}
+
+func (t *BPlusTree) Values() []interface{} {
+	values := make([]interface{}, t.size)
+	it := t.Iterator()
+	for i := 0; it.Next(); i++ {
+		values[i] = it.Value()
+	}
+	return values
+}
+
+func (t *BPlusTree) Clear() {
+	t.Root = nil
+	t.size = 0
+}
+
+func (t *BPlusTree) Height() int {
+	return t.Root.height()
+}
+
+// Left returns the left-most leaf.
+func (t *BPlusTree) Left() *BPlusNode {
+	if t.Empty() {
+		return nil
+	}
+	current := t.Root
+	for !current.isLeaf() {
+		current = current.Children[0]
+	}
+	return current
+}
+
+func (t *BPlusTree) LeftKey() interface{} {
+	if left := t.Left(); left != nil {
+		return left.Entries[0].Key
+	}
+	return nil
+}
+
+func (t *BPlusTree) LeftValue() interface{} {
+	if left := t.Left(); left != nil {
+		return left.Entries[0].Value
+	}
+	return nil
+}
+
+// Right returns the right-most leaf.
+func (t *BPlusTree) Right() *BPlusNode {
+	if t.Empty() {
+		return nil
+	}
+	current := t.Root
+	for !current.isLeaf() {
+		current = current.Children[len(current.Children)-1]
+	}
+	return current
+}
+
+func (t *BPlusTree) RightKey() interface{} {
+	if right := t.Right(); right != nil {
+		return right.Entries[len(right.Entries)-1].Key
+	}
+	return nil
+}
+
+func (t *BPlusTree) RightValue() interface{} {
+	if right := t.Right(); right != nil {
+		return right.Entries[len(right.Entries)-1].Value
+	}
+	return nil
+}
+
+func (t *BPlusTree) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("BPlusTree\n")
+	if !t.Empty() {
+		t.output(&buffer, t.Root, 0)
+	}
+	return buffer.String()
+}
+
+func (t *BPlusTree) output(buffer *bytes.Buffer, node *BPlusNode, level int) {
+	if node.isLeaf() {
+		for _, entry := range node.Entries {
+			buffer.WriteString(strings.Repeat("    ", level))
+			buffer.WriteString(fmt.Sprintf("%v", entry.Key) + "\n")
+		}
+		return
+	}
+	for e := 0; e < len(node.Children); e++ {
+		t.output(buffer, node.Children[e], level+1)
+		if e < len(node.Keys) {
+			buffer.WriteString(strings.Repeat("    ", level))
+			buffer.WriteString(fmt.Sprintf("[%v]", node.Keys[e]) + "\n")
+		}
+	}
+}
+
+func (t *BPlusTree) maxChildren() int {
+	return t.m
+}
+
+func (t *BPlusTree) minChildren() int {
+	// ceil(m/2)
+	return (t.m + 1) / 2
+}
+
+func (t *BPlusTree) maxEntries() int {
+	return t.m - 1
+}
+
+func (t *BPlusTree) minEntries() int {
+	return (t.m - 1) / 2
+}
+
+// findLeaf从根向下找到key所在的叶子节点，只需一次下降
+func (t *BPlusTree) findLeaf(key interface{}) *BPlusNode {
+	node := t.Root
+	for !node.isLeaf() {
+		node = node.Children[t.searchKeys(node, key)]
+	}
+	return node
+}
+
+// searchKeys返回key所在孩子的index，即Keys中小于等于key的数目
+func (t *BPlusTree) searchKeys(node *BPlusNode, key interface{}) int {
+	low, high := 0, len(node.Keys)-1
+	for low <= high {
+		mid := (low + high) / 2
+		if t.Comparator(key, node.Keys[mid]) >= 0 {
+			low = mid + 1
+		} else {
+			high = mid - 1
+		}
+	}
+	return low
+}
+
+// 二分，搜索叶子节点中key的位置
+func (t *BPlusTree) searchEntries(leaf *BPlusNode, key interface{}) (index int, found bool) {
+	low, high := 0, len(leaf.Entries)-1
+	for low <= high {
+		mid := (low + high) / 2
+		compare := t.Comparator(key, leaf.Entries[mid].Key)
+		switch {
+		case compare > 0:
+			low = mid + 1
+		case compare < 0:
+			high = mid - 1
+		default:
+			return mid, true
+		}
+	}
+	return low, false
+}
+
+func (t *BPlusTree) splitLeaf(leaf *BPlusNode) {
+	if len(leaf.Entries) <= t.maxEntries() {
+		return
+	}
+	middle := len(leaf.Entries) / 2
+	right := &BPlusNode{Entries: append([]*Entry(nil), leaf.Entries[middle:]...), Prev: leaf, Next: leaf.Next}
+	leaf.Entries = append([]*Entry(nil), leaf.Entries[:middle]...)
+	if leaf.Next != nil {
+		leaf.Next.Prev = right
+	}
+	leaf.Next = right
+	// 叶子split时复制而不是提升右叶子的第一个key
+	t.insertIntoParent(leaf, right.Entries[0].Key, right)
+}
+
+func (t *BPlusTree) splitInternal(node *BPlusNode) {
+	if len(node.Children) <= t.maxChildren() {
+		return
+	}
+	middle := len(node.Keys) / 2
+	right := &BPlusNode{
+		Keys:     append([]interface{}(nil), node.Keys[middle+1:]...),
+		Children: append([]*BPlusNode(nil), node.Children[middle+1:]...),
+	}
+	setBPlusParent(right.Children, right)
+	key := node.Keys[middle]
+	node.Keys = append([]interface{}(nil), node.Keys[:middle]...)
+	node.Children = append([]*BPlusNode(nil), node.Children[:middle+1]...)
+	// 内部节点split时提升middle key
+	t.insertIntoParent(node, key, right)
+}
+
+// insertIntoParent把split得到的right插入到left的parent中，key为两者的分隔key
+func (t *BPlusTree) insertIntoParent(left *BPlusNode, key interface{}, right *BPlusNode) {
+	parent := left.Parent
+	if parent == nil {
+		t.Root = &BPlusNode{Keys: []interface{}{key}, Children: []*BPlusNode{left, right}}
+		left.Parent = t.Root
+		right.Parent = t.Root
+		return
+	}
+	right.Parent = parent
+	index := childIndex(parent, left)
+	parent.Keys = append(parent.Keys, nil)
+	copy(parent.Keys[index+1:], parent.Keys[index:])
+	parent.Keys[index] = key
+	parent.Children = append(parent.Children, nil)
+	copy(parent.Children[index+2:], parent.Children[index+1:])
+	parent.Children[index+1] = right
+	t.splitInternal(parent)
+}
+
+func (t *BPlusTree) rebalanceLeaf(leaf *BPlusNode) {
+	if leaf == t.Root {
+		if len(leaf.Entries) == 0 {
+			t.Root = nil
+		}
+		return
+	}
+	if len(leaf.Entries) >= t.minEntries() && len(leaf.Entries) > 0 {
+		return
+	}
+	parent := leaf.Parent
+	index := childIndex(parent, leaf)
+
+	// 首先尝试从左兄弟借entry
+	if index > 0 {
+		left := parent.Children[index-1]
+		if len(left.Entries) > t.minEntries() {
+			leaf.Entries = append([]*Entry{left.Entries[len(left.Entries)-1]}, leaf.Entries...)
+			left.Entries[len(left.Entries)-1] = nil
+			left.Entries = left.Entries[:len(left.Entries)-1]
+			parent.Keys[index-1] = leaf.Entries[0].Key
+			return
+		}
+	}
+	// 尝试从右兄弟借entry
+	if index < len(parent.Children)-1 {
+		right := parent.Children[index+1]
+		if len(right.Entries) > t.minEntries() {
+			leaf.Entries = append(leaf.Entries, right.Entries[0])
+			copy(right.Entries, right.Entries[1:])
+			right.Entries[len(right.Entries)-1] = nil
+			right.Entries = right.Entries[:len(right.Entries)-1]
+			parent.Keys[index] = right.Entries[0].Key
+			return
+		}
+	}
+	// 合并到左侧的叶子，删除右侧的叶子
+	if index > 0 {
+		left := parent.Children[index-1]
+		left.Entries = append(left.Entries, leaf.Entries...)
+		t.unlinkLeaf(leaf)
+		t.deleteChild(parent, index-1, index)
+	} else {
+		right := parent.Children[index+1]
+		leaf.Entries = append(leaf.Entries, right.Entries...)
+		t.unlinkLeaf(right)
+		t.deleteChild(parent, index, index+1)
+	}
+	t.rebalanceInternal(parent)
+}
+
+func (t *BPlusTree) rebalanceInternal(node *BPlusNode) {
+	if node == t.Root {
+		if len(node.Children) == 1 {
+			t.Root = node.Children[0]
+			t.Root.Parent = nil
+		}
+		return
+	}
+	if len(node.Children) >= t.minChildren() {
+		return
+	}
+	parent := node.Parent
+	index := childIndex(parent, node)
+
+	// 从左兄弟借孩子, parent的分隔key下放, 左兄弟的最右key提升
+	if index > 0 {
+		left := parent.Children[index-1]
+		if len(left.Children) > t.minChildren() {
+			child := left.Children[len(left.Children)-1]
+			node.Keys = append([]interface{}{parent.Keys[index-1]}, node.Keys...)
+			node.Children = append([]*BPlusNode{child}, node.Children...)
+			child.Parent = node
+			parent.Keys[index-1] = left.Keys[len(left.Keys)-1]
+			left.Keys = left.Keys[:len(left.Keys)-1]
+			left.Children = left.Children[:len(left.Children)-1]
+			return
+		}
+	}
+	// 从右兄弟借孩子
+	if index < len(parent.Children)-1 {
+		right := parent.Children[index+1]
+		if len(right.Children) > t.minChildren() {
+			child := right.Children[0]
+			node.Keys = append(node.Keys, parent.Keys[index])
+			node.Children = append(node.Children, child)
+			child.Parent = node
+			parent.Keys[index] = right.Keys[0]
+			right.Keys = append([]interface{}(nil), right.Keys[1:]...)
+			right.Children = append([]*BPlusNode(nil), right.Children[1:]...)
+			return
+		}
+	}
+	// 以parent的分隔key为中间连接合并两个节点
+	if index > 0 {
+		left := parent.Children[index-1]
+		left.Keys = append(append(left.Keys, parent.Keys[index-1]), node.Keys...)
+		left.Children = append(left.Children, node.Children...)
+		setBPlusParent(node.Children, left)
+		t.deleteChild(parent, index-1, index)
+	} else {
+		right := parent.Children[index+1]
+		node.Keys = append(append(node.Keys, parent.Keys[index]), right.Keys...)
+		node.Children = append(node.Children, right.Children...)
+		setBPlusParent(right.Children, node)
+		t.deleteChild(parent, index, index+1)
+	}
+	t.rebalanceInternal(parent)
+}
+
+// deleteChild删除parent的第keyIndex个key和第childIndex个孩子
+func (t *BPlusTree) deleteChild(parent *BPlusNode, keyIndex int, childIndex int) {
+	copy(parent.Keys[keyIndex:], parent.Keys[keyIndex+1:])
+	parent.Keys[len(parent.Keys)-1] = nil
+	parent.Keys = parent.Keys[:len(parent.Keys)-1]
+	copy(parent.Children[childIndex:], parent.Children[childIndex+1:])
+	parent.Children[len(parent.Children)-1] = nil
+	parent.Children = parent.Children[:len(parent.Children)-1]
+}
+
+func (t *BPlusTree) unlinkLeaf(leaf *BPlusNode) {
+	if leaf.Prev != nil {
+		leaf.Prev.Next = leaf.Next
+	}
+	if leaf.Next != nil {
+		leaf.Next.Prev = leaf.Prev
+	}
+	leaf.Prev, leaf.Next = nil, nil
+}
+
+func childIndex(parent *BPlusNode, child *BPlusNode) int {
+	for i, c := range parent.Children {
+		if c == child {
+			return i
+		}
+	}
+	return -1
+}
+
+func setBPlusParent(nodes []*BPlusNode, parent *BPlusNode) {
+	for _, node := range nodes {
+		node.Parent = parent
+	}
+}
@@ -0,0 +1,365 @@
+package btree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestBPlusTreePutAndGet(t *testing.T) {
+	tree := NewBPlusWithIntComparator(3)
+	tree.Put(7, "g")
+	tree.Put(9, "i")
+	tree.Put(10, "j")
+	tree.Put(6, "f")
+	tree.Put(3, "c")
+	tree.Put(4, "d")
+	tree.Put(5, "e")
+	tree.Put(8, "h")
+	tree.Put(2, "b")
+	tree.Put(1, "x")
+	tree.Put(1, "a") // overwrite
+	assertValidBPlusTree(t, tree, 10)
+
+	tests := [][]interface{}{
+		{0, nil, false},
+		{1, "a", true},
+		{2, "b", true},
+		{3, "c", true},
+		{4, "d", true},
+		{5, "e", true},
+		{6, "f", true},
+		{7, "g", true},
+		{8, "h", true},
+		{9, "i", true},
+		{10, "j", true},
+		{11, nil, false},
+	}
+
+	for _, test := range tests {
+		if value, found := tree.Get(test[0]); value != test[1] || found != test[2] {
+			t.Errorf("Got %v,%v expected %v,%v", value, found, test[1], test[2])
+		}
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 2 3 4 5 6 7 8 9 10]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[a b c d e f g h i j]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeRemove(t *testing.T) {
+	tree := NewBPlusWithIntComparator(3)
+	for i := 1; i <= 10; i++ {
+		tree.Put(i, i)
+	}
+	tree.Remove(11)
+	assertValidBPlusTree(t, tree, 10)
+	for _, key := range []int{5, 1, 10, 3, 7, 2} {
+		tree.Remove(key)
+	}
+	assertValidBPlusTree(t, tree, 4)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[4 6 8 9]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for _, key := range []int{4, 6, 8, 9} {
+		tree.Remove(key)
+	}
+	assertValidBPlusTree(t, tree, 0)
+	if actualValue := tree.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := tree.Height(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestBPlusTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	for _, order := range []int{3, 4, 5, 8} {
+		tree := NewBPlusWithIntComparator(order)
+		present := map[int]bool{}
+		for i := 0; i < 3000; i++ {
+			key := r.Intn(400)
+			if r.Intn(2) == 0 {
+				tree.Remove(key)
+				delete(present, key)
+			} else {
+				tree.Put(key, key)
+				present[key] = true
+			}
+			if i%100 == 0 {
+				assertValidBPlusTree(t, tree, len(present))
+			}
+		}
+		assertValidBPlusTree(t, tree, len(present))
+		for key := range present {
+			if value, found := tree.Get(key); value != key || !found {
+				t.Errorf("Got %v,%v expected %v,%v", value, found, key, true)
+			}
+		}
+	}
+}
+
+func TestBPlusTreeLeftAndRight(t *testing.T) {
+	tree := NewBPlusWithIntComparator(3)
+
+	if actualValue := tree.Left(); actualValue != nil {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue := tree.Right(); actualValue != nil {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	tree.Put(1, "a")
+	tree.Put(5, "e")
+	tree.Put(6, "f")
+	tree.Put(7, "g")
+	tree.Put(3, "c")
+	tree.Put(4, "d")
+	tree.Put(1, "x") // overwrite
+	tree.Put(2, "b")
+
+	if actualValue, expectedValue := tree.LeftKey(), 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.LeftValue(), "x"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.RightKey(), 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.RightValue(), "g"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBPlusTreeIterator(t *testing.T) {
+	tree := NewBPlusWithIntComparator(3)
+	it := tree.Iterator()
+	for it.Next() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+	for it.Prev() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+
+	for i := 1; i <= 7; i++ {
+		tree.Put(i, i)
+	}
+	it = tree.Iterator()
+	count := 0
+	for it.Next() {
+		count++
+		if actualValue, expectedValue := it.Key(), count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue, expectedValue := count, tree.Size(); actualValue != expectedValue {
+		t.Errorf("Size different. Got %v expected %v", actualValue, expectedValue)
+	}
+	for it.Prev() {
+		if actualValue, expectedValue := it.Key(), count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		count--
+	}
+	if actualValue, expectedValue := count, 0; actualValue != expectedValue {
+		t.Errorf("Size different. Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue, expectedValue := it.First(), true; actualValue != expectedValue || it.Key() != 1 {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), expectedValue, 1)
+	}
+	if actualValue, expectedValue := it.Last(), true; actualValue != expectedValue || it.Key() != 7 {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), expectedValue, 7)
+	}
+	it.End()
+	if it.Key() != nil {
+		t.Errorf("Got %v expected %v", it.Key(), nil)
+	}
+}
+
+func TestBPlusTreeIteratorSeek(t *testing.T) {
+	tree := NewBPlusWithIntComparator(3)
+	it := tree.Iterator()
+	if actualValue, expectedValue := it.Seek(1), false; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for i := 0; i < 20; i += 2 {
+		tree.Put(i, i)
+	}
+
+	tests := [][]interface{}{
+		{-5, true, 0},
+		{0, true, 0},
+		{7, true, 8},
+		{8, true, 8},
+		{18, true, 18},
+		{19, false, nil},
+	}
+	for _, test := range tests {
+		if actualValue, expectedValue := it.Seek(test[0]), test[1]; actualValue != expectedValue || it.Key() != test[2] {
+			t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), expectedValue, test[2])
+		}
+	}
+
+	it.Seek(7)
+	keys := []interface{}{}
+	for ok := true; ok && it.Key().(int) < 14; ok = it.Next() {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[8 10 12]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.Seek(8)
+	if actualValue, expectedValue := it.Prev(), true; actualValue != expectedValue || it.Key() != 6 {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), expectedValue, 6)
+	}
+}
+
+func TestBPlusTreeSerialization(t *testing.T) {
+	tree := NewBPlusWithIntComparator(3)
+	for i := 0; i < 10; i++ {
+		tree.Put(i, fmt.Sprint(i))
+	}
+	data, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	keys := fmt.Sprintf("%#v", tree.Keys())
+	if err = tree.FromJSON(data); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	assertValidBPlusTree(t, tree, 10)
+	if actualValue, expectedValue := fmt.Sprintf("%#v", tree.Keys()), keys; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+// assertValidBPlusTree checks occupancy, separator keys, parent pointers, leaf depth and leaf links.
+func assertValidBPlusTree(t *testing.T, tree *BPlusTree, expectedSize int) {
+	if actualValue, expectedValue := tree.Size(), expectedSize; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v for tree size", actualValue, expectedValue)
+	}
+	if tree.Root == nil {
+		return
+	}
+	leaves := []*BPlusNode{}
+	var check func(node *BPlusNode, parent *BPlusNode, depth int, low interface{}, high interface{})
+	check = func(node *BPlusNode, parent *BPlusNode, depth int, low interface{}, high interface{}) {
+		if node.Parent != parent {
+			t.Errorf("Wrong parent for node %v", node.Keys)
+		}
+		inRange := func(key interface{}) bool {
+			return (low == nil || tree.Comparator(key, low) >= 0) && (high == nil || tree.Comparator(key, high) < 0)
+		}
+		if node.isLeaf() {
+			if depth != tree.Height() {
+				t.Errorf("Got leaf depth %v expected %v", depth, tree.Height())
+			}
+			if node != tree.Root && (len(node.Entries) < tree.minEntries() || len(node.Entries) == 0) {
+				t.Errorf("Leaf underflow: %v entries", len(node.Entries))
+			}
+			if len(node.Entries) > tree.maxEntries() {
+				t.Errorf("Leaf overflow: %v entries", len(node.Entries))
+			}
+			for _, entry := range node.Entries {
+				if !inRange(entry.Key) {
+					t.Errorf("Key %v out of range [%v, %v)", entry.Key, low, high)
+				}
+			}
+			leaves = append(leaves, node)
+			return
+		}
+		if len(node.Children) != len(node.Keys)+1 {
+			t.Errorf("Got %v children for %v keys", len(node.Children), len(node.Keys))
+		}
+		if node != tree.Root && len(node.Children) < tree.minChildren() {
+			t.Errorf("Internal underflow: %v children", len(node.Children))
+		}
+		if len(node.Children) > tree.maxChildren() {
+			t.Errorf("Internal overflow: %v children", len(node.Children))
+		}
+		for i, child := range node.Children {
+			childLow, childHigh := low, high
+			if i > 0 {
+				childLow = node.Keys[i-1]
+			}
+			if i < len(node.Keys) {
+				childHigh = node.Keys[i]
+			}
+			check(child, node, depth+1, childLow, childHigh)
+		}
+	}
+	check(tree.Root, nil, 1, nil, nil)
+	for i, leaf := range leaves {
+		if i > 0 && leaf.Prev != leaves[i-1] {
+			t.Errorf("Wrong Prev link for leaf %v", i)
+		}
+		if i < len(leaves)-1 && leaf.Next != leaves[i+1] {
+			t.Errorf("Wrong Next link for leaf %v", i)
+		}
+	}
+	if leaves[0].Prev != nil || leaves[len(leaves)-1].Next != nil {
+		t.Errorf("Outer leaves should not be linked")
+	}
+}
+
+func benchmarkBPlusGet(b *testing.B, tree *BPlusTree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Get(n)
+		}
+	}
+}
+
+func benchmarkBPlusIterate(b *testing.B, tree *BPlusTree) {
+	for i := 0; i < b.N; i++ {
+		it := tree.Iterator()
+		for it.Next() {
+		}
+	}
+}
+
+func benchmarkIterate(b *testing.B, tree *Tree) {
+	for i := 0; i < b.N; i++ {
+		it := tree.Iterator()
+		for it.Next() {
+		}
+	}
+}
+
+func BenchmarkBPlusTreeGet10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	tree := NewBPlusWithIntComparator(128)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkBPlusGet(b, tree, size)
+}
+
+func BenchmarkBPlusTreeIterate10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	tree := NewBPlusWithIntComparator(128)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkBPlusIterate(b, tree)
+}
+
+func BenchmarkBTreeIterate10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	tree := NewWithIntComparator(128)
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkIterate(b, tree)
+}
@@ -5,8 +5,13 @@
 package btree
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/container/containertest"
+	"github.com/morganxf/algorithm/util"
 )
 
 func TestBTreeGet1(t *testing.T) {
@@ -73,6 +78,39 @@ func TestBTreeGet2(t *testing.T) {
 	}
 }
 
+func TestBTreeCeilingAndFloor(t *testing.T) {
+	tree := NewWithIntComparator(3)
+
+	if entry, found := tree.Floor(0); entry != nil || found {
+		t.Errorf("Got %v expected %v", entry, "<nil>")
+	}
+	if entry, found := tree.Ceiling(0); entry != nil || found {
+		t.Errorf("Got %v expected %v", entry, "<nil>")
+	}
+
+	for key := 2; key <= 20; key += 2 {
+		tree.Put(key, key)
+	}
+
+	tests := [][]interface{}{
+		{1, nil, 2},
+		{2, 2, 2},
+		{7, 6, 8},
+		{12, 12, 12},
+		{15, 14, 16},
+		{20, 20, 20},
+		{21, 20, nil},
+	}
+	for _, test := range tests {
+		if entry, found := tree.Floor(test[0]); (found && entry.Key != test[1]) || found != (test[1] != nil) {
+			t.Errorf("Got %v expected %v", entry, test[1])
+		}
+		if entry, found := tree.Ceiling(test[0]); (found && entry.Key != test[2]) || found != (test[2] != nil) {
+			t.Errorf("Got %v expected %v", entry, test[2])
+		}
+	}
+}
+
 func TestBTreePut1(t *testing.T) {
 	// https://upload.wikimedia.org/wikipedia/commons/3/33/B_tree_insertion_example.png
 	tree := NewWithIntComparator(3)
@@ -1105,6 +1143,105 @@ func TestBTreeSerialization(t *testing.T) {
 	assert()
 }
 
+func TestBTreeSerializationKeyTypes(t *testing.T) {
+	ints := NewWithIntComparator(3)
+	ints.Put(10, "a")
+	ints.Put(2, "b")
+	ints.Put(33, "c")
+	floats := NewWith(3, func(a, b interface{}) int {
+		return int(a.(float64)*4 - b.(float64)*4)
+	})
+	floats.Put(1.5, 1)
+	floats.Put(-0.25, 2)
+
+	for _, tree := range []*Tree{ints, floats} {
+		data, err := tree.ToJSON()
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		keys := fmt.Sprintf("%#v", tree.Keys())
+		values := fmt.Sprint(tree.Values())
+		if err = tree.FromJSON(data); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+		if actualValue, expectedValue := fmt.Sprintf("%#v", tree.Keys()), keys; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Values()), values; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	if data, _ := ints.ToJSON(); string(data) != `[{"type":"int","key":2,"value":"b"},{"type":"int","key":10,"value":"a"},{"type":"int","key":33,"value":"c"}]` {
+		t.Errorf("Got %s", data)
+	}
+}
+
+func TestBTreeSerializationCustomKey(t *testing.T) {
+	type point struct{ X, Y int }
+	comparator := func(a, b interface{}) int {
+		p1, p2 := a.(point), b.(point)
+		if c := util.IntComparator(p1.X, p2.X); c != 0 {
+			return c
+		}
+		return util.IntComparator(p1.Y, p2.Y)
+	}
+	tree := NewWith(3, comparator)
+	tree.Put(point{2, 1}, "b")
+	tree.Put(point{1, 2}, "a")
+	data, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	if err = tree.FromJSON(data); err != util.ErrNoKeyDecoder {
+		t.Errorf("Got %v expected %v", err, util.ErrNoKeyDecoder)
+	}
+	if actualValue, expectedValue := tree.Size(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tree.KeyDecoder = func(data []byte) (interface{}, error) {
+		var p point
+		err := json.Unmarshal(data, &p)
+		return p, err
+	}
+	if err = tree.FromJSON(data); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[{1 2} {2 1}]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestBTreeMarshalJSON(t *testing.T) {
+	type document struct {
+		Name  string
+		Index *Tree
+	}
+	doc := document{Name: "doc", Index: NewWithStringComparator(3)}
+	doc.Index.Put("b", 2)
+	doc.Index.Put("a", 1)
+	doc.Index.Put("c", 3)
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	restored := document{Index: NewWithStringComparator(3)}
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(restored.Name, restored.Index.Keys(), restored.Index.Values()), "doc[a b c] [1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	// 零值没有comparator, 无法解码
+	if err := json.Unmarshal(data, &document{}); err != util.ErrNotConstructed {
+		t.Errorf("Got %v expected %v", err, util.ErrNotConstructed)
+	}
+}
+
 func benchmarkGet(b *testing.B, tree *Tree, size int) {
 	for i := 0; i < b.N; i++ {
 		for n := 0; n < size; n++ {
@@ -1257,3 +1394,9 @@ func BenchmarkBTreeRemove100000(b *testing.B) {
 	b.StartTimer()
 	benchmarkRemove(b, tree, size)
 }
+
+func TestBTreeSortedMap(t *testing.T) {
+	for order := 3; order <= 6; order++ {
+		containertest.TestSortedMap(t, func() container.SortedMap { return NewWithIntComparator(order) })
+	}
+}
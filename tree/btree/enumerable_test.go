@@ -0,0 +1,87 @@
+package btree
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func newEnumerableTree() *Tree {
+	tree := NewWithStringComparator(3)
+	tree.Put("c", 3)
+	tree.Put("a", 1)
+	tree.Put("b", 2)
+	return tree
+}
+
+func TestTreeEach(t *testing.T) {
+	tree := newEnumerableTree()
+	count := 0
+	tree.Each(func(key interface{}, value interface{}) {
+		count++
+		if actualValue, expectedValue := fmt.Sprint(key, value), fmt.Sprint(string(rune('a'+count-1)), count); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	})
+	if count != 3 {
+		t.Errorf("Got %v expected %v", count, 3)
+	}
+}
+
+func TestTreeMap(t *testing.T) {
+	tree := newEnumerableTree()
+	mapped := tree.Map(func(key interface{}, value interface{}) (interface{}, interface{}) {
+		return strings.Repeat(key.(string), 2), value.(int) * value.(int)
+	})
+	if actualValue, expectedValue := fmt.Sprint(mapped.Keys(), mapped.Values()), "[aa bb cc] [1 4 9]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	// 新树使用相同的comparator
+	mapped.Put("ab", 0)
+	if actualValue, expectedValue := fmt.Sprint(mapped.Keys()), "[aa ab bb cc]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := tree.Size(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+}
+
+func TestTreeSelectFunc(t *testing.T) {
+	tree := newEnumerableTree()
+	selected := tree.SelectFunc(func(key interface{}, value interface{}) bool {
+		return value.(int) >= 2
+	})
+	if actualValue, expectedValue := fmt.Sprint(selected.Keys(), selected.Values()), "[b c] [2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	selected.Put("a", 1)
+	if actualValue, expectedValue := fmt.Sprint(selected.Keys()), "[a b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestTreeAnyAllFind(t *testing.T) {
+	tree := newEnumerableTree()
+	positive := func(key interface{}, value interface{}) bool { return value.(int) > 0 }
+	even := func(key interface{}, value interface{}) bool { return value.(int)%2 == 0 }
+	large := func(key interface{}, value interface{}) bool { return value.(int) > 3 }
+
+	if actualValue := tree.All(positive); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := tree.All(even); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := tree.Any(even); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := tree.Any(large); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if key, value := tree.Find(positive); key != "a" || value != 1 {
+		t.Errorf("Got %v,%v expected %v,%v", key, value, "a", 1)
+	}
+	if key, value := tree.Find(large); key != nil || value != nil {
+		t.Errorf("Got %v,%v expected %v,%v", key, value, nil, nil)
+	}
+}
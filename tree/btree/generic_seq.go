@@ -0,0 +1,62 @@
+package btree
+
+import (
+	"iter"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+// AllSeq is the type-parameterized counterpart of Tree.AllSeq.
+func (t *TreeOf[K, V]) AllSeq() iter.Seq2[K, V] {
+	return typedSeq2[K, V](t.tree.AllSeq())
+}
+
+// BackwardSeq is the type-parameterized counterpart of Tree.BackwardSeq.
+func (t *TreeOf[K, V]) BackwardSeq() iter.Seq2[K, V] {
+	return typedSeq2[K, V](t.tree.BackwardSeq())
+}
+
+// KeysSeq is the type-parameterized counterpart of Tree.KeysSeq.
+func (t *TreeOf[K, V]) KeysSeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range t.tree.AllSeq() {
+			if !yield(key.(K)) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesSeq is the type-parameterized counterpart of Tree.ValuesSeq.
+func (t *TreeOf[K, V]) ValuesSeq() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range t.tree.AllSeq() {
+			v, _ := value.(V)
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// RangeSeq is the type-parameterized counterpart of Tree.RangeSeq.
+// util.UnboundedFrom or util.UnboundedTo take the place of a nil from or to.
+func (t *TreeOf[K, V]) RangeSeq(from K, to K, inclusion util.Inclusion) iter.Seq2[K, V] {
+	return typedSeq2[K, V](t.tree.RangeSeq(from, to, inclusion))
+}
+
+// RangeBackwardSeq is the type-parameterized counterpart of Tree.RangeBackwardSeq.
+func (t *TreeOf[K, V]) RangeBackwardSeq(from K, to K, inclusion util.Inclusion) iter.Seq2[K, V] {
+	return typedSeq2[K, V](t.tree.RangeBackwardSeq(from, to, inclusion))
+}
+
+func typedSeq2[K, V any](seq iter.Seq2[interface{}, interface{}]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, value := range seq {
+			v, _ := value.(V)
+			if !yield(key.(K), v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package btree
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func TestTreeOfPutGetRemove(t *testing.T) {
+	tree := NewOrdered[int, string](3)
+	for i, value := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		tree.Put(i+1, value)
+	}
+
+	if actualValue := tree.Size(); actualValue != 7 {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+	if actualValue := tree.Height(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, expectedValue := strings.Join(tree.Values(), ""), "abcdefg"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if value, found := tree.Get(4); value != "d" || !found {
+		t.Errorf("Got %v,%v expected %v,%v", value, found, "d", true)
+	}
+	if value, found := tree.Get(8); value != "" || found {
+		t.Errorf("Got %v,%v expected %v,%v", value, found, "", false)
+	}
+
+	tree.Remove(1)
+	tree.Remove(7)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[2 3 4 5 6]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if key, value, found := tree.Left(); key != 2 || value != "b" || !found {
+		t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, 2, "b", true)
+	}
+	if key, value, found := tree.Right(); key != 6 || value != "f" || !found {
+		t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, 6, "f", true)
+	}
+
+	tests := [][]interface{}{
+		{1, 0, "", false, 2, "b", true},
+		{4, 4, "d", true, 4, "d", true},
+		{7, 6, "f", true, 0, "", false},
+	}
+	for _, test := range tests {
+		if key, value, found := tree.Floor(test[0].(int)); key != test[1] || value != test[2] || found != test[3] {
+			t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, test[1], test[2], test[3])
+		}
+		if key, value, found := tree.Ceiling(test[0].(int)); key != test[4] || value != test[5] || found != test[6] {
+			t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, test[4], test[5], test[6])
+		}
+	}
+
+	tree.Clear()
+	if key, value, found := tree.Left(); key != 0 || value != "" || found {
+		t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, 0, "", false)
+	}
+}
+
+func TestTreeOfIterator(t *testing.T) {
+	tree := NewOf[string, int](3, strings.Compare)
+	for i, key := range []string{"d", "b", "a", "c", "e"} {
+		tree.Put(key, i)
+	}
+
+	it := tree.Iterator()
+	keys := ""
+	for it.Next() {
+		keys += it.Key()
+	}
+	if keys != "abcde" {
+		t.Errorf("Got %v expected %v", keys, "abcde")
+	}
+	if it.Key() != "" || it.Value() != 0 {
+		t.Errorf("Got %v,%v expected %v,%v", it.Key(), it.Value(), "", 0)
+	}
+	if !it.Last() || it.Key() != "e" || it.Value() != 4 {
+		t.Errorf("Got %v,%v expected %v,%v", it.Key(), it.Value(), "e", 4)
+	}
+}
+
+func TestTreeOfSerialization(t *testing.T) {
+	tree := NewOrdered[string, int](3)
+	tree.Put("b", 2)
+	tree.Put("a", 1)
+	tree.Put("c", 3)
+
+	json, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	restored := NewOrdered[string, int](3)
+	if err := restored.FromJSON(json); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(restored.Keys(), restored.Values()), "[a b c] [1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestTreeOfSeq(t *testing.T) {
+	tree := NewOrdered[int, string](3)
+	tree.Put(2, "b")
+	tree.Put(1, "a")
+	tree.Put(3, "c")
+
+	result := ""
+	for key, value := range tree.AllSeq() {
+		result += fmt.Sprint(key) + value
+	}
+	for value := range tree.ValuesSeq() {
+		result += value
+	}
+	for key, value := range tree.RangeBackwardSeq(1, 3, util.IncludeTo) {
+		result += fmt.Sprint(key) + value
+	}
+	for key, value := range tree.RangeSeq(2, 0, util.IncludeFrom|util.UnboundedTo) {
+		result += fmt.Sprint(key) + value
+	}
+	if expectedValue := "1a2b3cabc3c2b2b3c"; result != expectedValue {
+		t.Errorf("Got %v expected %v", result, expectedValue)
+	}
+}
@@ -0,0 +1,98 @@
+package btree
+
+import (
+	"iter"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+// AllSeq returns an iterator over the key/value pairs of the tree in key order, for use with range.
+func (t *Tree) AllSeq() iter.Seq2[interface{}, interface{}] {
+	return t.RangeSeq(nil, nil, util.IncludeBoth)
+}
+
+// BackwardSeq returns an iterator over the key/value pairs of the tree in reverse key order.
+func (t *Tree) BackwardSeq() iter.Seq2[interface{}, interface{}] {
+	return t.RangeBackwardSeq(nil, nil, util.IncludeBoth)
+}
+
+// KeysSeq returns an iterator over the keys of the tree in order.
+func (t *Tree) KeysSeq() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for key := range t.AllSeq() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// ValuesSeq returns an iterator over the values of the tree in key order.
+func (t *Tree) ValuesSeq() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, value := range t.AllSeq() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// RangeSeq returns an iterator over the key/value pairs whose keys are between from and to, in key order.
+// A nil from or to, or util.UnboundedFrom or util.UnboundedTo, leaves that end of the range unbounded.
+func (t *Tree) RangeSeq(from interface{}, to interface{}, inclusion util.Inclusion) iter.Seq2[interface{}, interface{}] {
+	from, to = inclusion.Bounds(from, to)
+	return func(yield func(interface{}, interface{}) bool) {
+		it := t.Iterator()
+		ok := false
+		if from == nil {
+			ok = it.Next()
+		} else if node, index := t.ceiling(from); node != nil {
+			// 定位到下界, 不包含下界时跳过与from相等的entry
+			it.node, it.entry, it.position = node, node.Entries[index], between
+			ok = true
+			if inclusion&util.IncludeFrom == 0 && t.Comparator(it.entry.Key, from) == 0 {
+				ok = it.Next()
+			}
+		}
+		for ; ok; ok = it.Next() {
+			if to != nil {
+				if cmp := t.Comparator(it.entry.Key, to); cmp > 0 || (cmp == 0 && inclusion&util.IncludeTo == 0) {
+					return
+				}
+			}
+			if !yield(it.entry.Key, it.entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+// RangeBackwardSeq returns an iterator over the key/value pairs whose keys are between from and to, in reverse key order.
+func (t *Tree) RangeBackwardSeq(from interface{}, to interface{}, inclusion util.Inclusion) iter.Seq2[interface{}, interface{}] {
+	from, to = inclusion.Bounds(from, to)
+	return func(yield func(interface{}, interface{}) bool) {
+		it := t.Iterator()
+		it.End()
+		ok := false
+		if to == nil {
+			ok = it.Prev()
+		} else if node, index := t.floor(to); node != nil {
+			it.node, it.entry, it.position = node, node.Entries[index], between
+			ok = true
+			if inclusion&util.IncludeTo == 0 && t.Comparator(it.entry.Key, to) == 0 {
+				ok = it.Prev()
+			}
+		}
+		for ; ok; ok = it.Prev() {
+			if from != nil {
+				if cmp := t.Comparator(it.entry.Key, from); cmp < 0 || (cmp == 0 && inclusion&util.IncludeFrom == 0) {
+					return
+				}
+			}
+			if !yield(it.entry.Key, it.entry.Value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package btree
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func TestTreeAllSeq(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	for _, key := range []int{5, 1, 4, 2, 3} {
+		tree.Put(key, fmt.Sprint(key))
+	}
+
+	keys, values := []interface{}{}, []interface{}{}
+	for key, value := range tree.AllSeq() {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys, values), "[1 2 3 4 5] [1 2 3 4 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	keys = keys[:0]
+	for key := range tree.BackwardSeq() {
+		keys = append(keys, key)
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[5 4 3 2 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	keys = keys[:0]
+	for key := range tree.KeysSeq() {
+		if key == 3 {
+			break
+		}
+		keys = append(keys, key)
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[1 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	values = values[:0]
+	for value := range tree.ValuesSeq() {
+		values = append(values, value)
+	}
+	if actualValue, expectedValue := fmt.Sprint(values), "[1 2 3 4 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestTreeRangeSeq(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	for key := 1; key <= 9; key += 2 {
+		tree.Put(key, key)
+	}
+
+	tests := [][]interface{}{
+		{3, 7, util.IncludeBoth, "[3 5 7]", "[7 5 3]"},
+		{3, 7, util.IncludeNone, "[5]", "[5]"},
+		{3, 7, util.IncludeFrom, "[3 5]", "[5 3]"},
+		{3, 7, util.IncludeTo, "[5 7]", "[7 5]"},
+		{2, 8, util.IncludeNone, "[3 5 7]", "[7 5 3]"},
+		{nil, 4, util.IncludeNone, "[1 3]", "[3 1]"},
+		{6, nil, util.IncludeNone, "[7 9]", "[9 7]"},
+		{7, 3, util.IncludeBoth, "[]", "[]"},
+	}
+	for _, test := range tests {
+		keys := []interface{}{}
+		for key := range tree.RangeSeq(test[0], test[1], test[2].(util.Inclusion)) {
+			keys = append(keys, key)
+		}
+		if actualValue := fmt.Sprint(keys); actualValue != test[3] {
+			t.Errorf("Got %v expected %v", actualValue, test[3])
+		}
+		keys = keys[:0]
+		for key := range tree.RangeBackwardSeq(test[0], test[1], test[2].(util.Inclusion)) {
+			keys = append(keys, key)
+		}
+		if actualValue := fmt.Sprint(keys); actualValue != test[4] {
+			t.Errorf("Got %v expected %v", actualValue, test[4])
+		}
+	}
+}
+
+func TestTreeAllSeqAllocations(t *testing.T) {
+	tree := NewWithIntComparator(3)
+	for i := 0; i < 1000; i++ {
+		tree.Put(i, i)
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		for range tree.AllSeq() {
+		}
+		for range tree.RangeSeq(100, 900, util.IncludeBoth) {
+		}
+	})
+	if allocs > 4 {
+		t.Errorf("Got %v allocations expected at most %v", allocs, 4)
+	}
+}
+
+func TestTreeRangeSeqRandom(t *testing.T) {
+	for order := 3; order <= 5; order++ {
+		tree := NewWithIntComparator(order)
+		for key := 0; key < 200; key += 3 {
+			tree.Put(key, key)
+		}
+		for from := -2; from < 202; from += 7 {
+			for to := from; to < 204; to += 11 {
+				for inclusion := util.IncludeNone; inclusion <= util.IncludeBoth; inclusion++ {
+					expected := []interface{}{}
+					for key := 0; key < 200; key += 3 {
+						if (key > from || (key == from && inclusion&util.IncludeFrom != 0)) && (key < to || (key == to && inclusion&util.IncludeTo != 0)) {
+							expected = append(expected, key)
+						}
+					}
+					actual := []interface{}{}
+					for key := range tree.RangeSeq(from, to, inclusion) {
+						actual = append(actual, key)
+					}
+					if fmt.Sprint(actual) != fmt.Sprint(expected) {
+						t.Errorf("Got %v expected %v", actual, expected)
+					}
+					actual = actual[:0]
+					for key := range tree.RangeBackwardSeq(from, to, inclusion) {
+						actual = append([]interface{}{key}, actual...)
+					}
+					if fmt.Sprint(actual) != fmt.Sprint(expected) {
+						t.Errorf("Got %v expected %v", actual, expected)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBPlusTreeAllSeq(t *testing.T) {
+	tree := NewBPlusWithIntComparator(3)
+	for _, key := range []int{5, 1, 4, 2, 3} {
+		tree.Put(key, fmt.Sprint(key))
+	}
+
+	keys, values := []interface{}{}, []interface{}{}
+	for key, value := range tree.AllSeq() {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys, values), "[1 2 3 4 5] [1 2 3 4 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	keys = keys[:0]
+	for key := range tree.BackwardSeq() {
+		keys = append(keys, key)
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[5 4 3 2 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	keys = keys[:0]
+	for key := range tree.KeysSeq() {
+		if key == 3 {
+			break
+		}
+		keys = append(keys, key)
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[1 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	values = values[:0]
+	for value := range tree.ValuesSeq() {
+		values = append(values, value)
+	}
+	if actualValue, expectedValue := fmt.Sprint(values), "[1 2 3 4 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for range NewBPlusWithIntComparator(3).BackwardSeq() {
+		t.Errorf("Got an entry from an empty tree")
+	}
+}
+
+func TestBPlusTreeRangeSeqRandom(t *testing.T) {
+	for order := 3; order <= 5; order++ {
+		tree := NewBPlusWithIntComparator(order)
+		for key := 0; key < 200; key += 3 {
+			tree.Put(key, key)
+		}
+		for from := -2; from < 202; from += 7 {
+			for to := from; to < 204; to += 11 {
+				for inclusion := util.IncludeNone; inclusion <= util.IncludeBoth; inclusion++ {
+					expected := []interface{}{}
+					for key := 0; key < 200; key += 3 {
+						if (key > from || (key == from && inclusion&util.IncludeFrom != 0)) && (key < to || (key == to && inclusion&util.IncludeTo != 0)) {
+							expected = append(expected, key)
+						}
+					}
+					actual := []interface{}{}
+					for key := range tree.RangeSeq(from, to, inclusion) {
+						actual = append(actual, key)
+					}
+					if fmt.Sprint(actual) != fmt.Sprint(expected) {
+						t.Errorf("Got %v expected %v", actual, expected)
+					}
+					actual = actual[:0]
+					for key := range tree.RangeBackwardSeq(from, to, inclusion) {
+						actual = append([]interface{}{key}, actual...)
+					}
+					if fmt.Sprint(actual) != fmt.Sprint(expected) {
+						t.Errorf("Got %v expected %v", actual, expected)
+					}
+				}
+			}
+		}
+		// 只有一端有界
+		actual := []interface{}{}
+		for key := range tree.RangeSeq(190, 0, util.IncludeNone|util.UnboundedTo) {
+			actual = append(actual, key)
+		}
+		if actualValue, expectedValue := fmt.Sprint(actual), "[192 195 198]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		actual = actual[:0]
+		for key := range tree.RangeBackwardSeq(0, 6, util.IncludeBoth|util.UnboundedFrom) {
+			actual = append(actual, key)
+		}
+		if actualValue, expectedValue := fmt.Sprint(actual), "[6 3 0]"; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package btree
+
+import "github.com/morganxf/algorithm/container"
+
+func assertSortedMapImplementation() {
+	var _ container.SortedMap = (*Tree)(nil)
+}
+
+func (t *Tree) Min() (key interface{}, value interface{}, found bool) {
+	if t.Empty() {
+		return nil, nil, false
+	}
+	return t.LeftKey(), t.LeftValue(), true
+}
+
+func (t *Tree) Max() (key interface{}, value interface{}, found bool) {
+	if t.Empty() {
+		return nil, nil, false
+	}
+	return t.RightKey(), t.RightValue(), true
+}
+
+func (t *Tree) FloorEntry(key interface{}) (floorKey interface{}, floorValue interface{}, found bool) {
+	return entry(t.Floor(key))
+}
+
+func (t *Tree) CeilingEntry(key interface{}) (ceilingKey interface{}, ceilingValue interface{}, found bool) {
+	return entry(t.Ceiling(key))
+}
+
+func (t *Tree) EntryIterator() container.ReverseIteratorWithKey {
+	it := t.Iterator()
+	return &it
+}
+
+func entry(e *Entry, found bool) (key interface{}, value interface{}, ok bool) {
+	if !found {
+		return nil, nil, false
+	}
+	return e.Key, e.Value, true
+}
@@ -0,0 +1,160 @@
+package fenwicktree
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/util"
+)
+
+func assertTreeImplementation() {
+	var _ container.Container = (*Tree)(nil)
+}
+
+// Tree is a Fenwick (binary indexed) tree over a fixed number of elements. It is smaller and faster than a
+// segment tree, but Query(l, r) and Update need to undo a combination, so the monoid must be commutative
+// and have an Inverse, e.g. util.IntSumMonoid. Use segmenttree for min or max.
+type Tree struct {
+	Monoid util.Monoid
+	values []interface{}
+	nodes  []interface{} // 以1为起始点, nodes[i]为元素(i-lowbit(i), i]的组合值
+}
+
+// NewWith returns a tree over values combined by monoid, it is built in O(n).
+// It panics if monoid has no Inverse.
+func NewWith(monoid util.Monoid, values []interface{}) *Tree {
+	if monoid.Inverse == nil {
+		panic("Invalid monoid, Fenwick tree requires an inverse")
+	}
+	t := &Tree{Monoid: monoid}
+	t.build(values)
+	return t
+}
+
+func NewWithIntSum(values []int) *Tree {
+	ints := make([]interface{}, len(values))
+	for i, v := range values {
+		ints[i] = v
+	}
+	return NewWith(util.IntSumMonoid, ints)
+}
+
+// Prefix returns the combination of the elements from index 0 to index r, both inclusive.
+func (t *Tree) Prefix(r int) interface{} {
+	if r < 0 {
+		return t.Monoid.Identity
+	}
+	t.checkIndex(r)
+	result := t.Monoid.Identity
+	for i := r + 1; i > 0; i -= i & -i {
+		result = t.Monoid.Combine(t.nodes[i], result)
+	}
+	return result
+}
+
+// Query returns the combination of the elements from index l to index r, both inclusive.
+// It returns the Identity if l > r and panics if l or r is out of range.
+func (t *Tree) Query(l int, r int) interface{} {
+	if l > r {
+		return t.Monoid.Identity
+	}
+	t.checkIndex(l)
+	return t.Monoid.Combine(t.Monoid.Inverse(t.Prefix(l-1)), t.Prefix(r))
+}
+
+// Get returns the element at index i.
+func (t *Tree) Get(i int) interface{} {
+	t.checkIndex(i)
+	return t.values[i]
+}
+
+// Update assigns value to the element at index i.
+func (t *Tree) Update(i int, value interface{}) {
+	t.checkIndex(i)
+	delta := t.Monoid.Combine(value, t.Monoid.Inverse(t.values[i]))
+	t.values[i] = value
+	for j := i + 1; j < len(t.nodes); j += j & -j {
+		t.nodes[j] = t.Monoid.Combine(t.nodes[j], delta)
+	}
+}
+
+// RangeUpdate assigns value to every element from index l to index r, both inclusive.
+// It costs O(min(k log n, n)) for k elements, there is no lazy propagation as in segmenttree.
+func (t *Tree) RangeUpdate(l int, r int, value interface{}) {
+	if l > r {
+		return
+	}
+	t.checkIndex(l)
+	t.checkIndex(r)
+	if k := r - l + 1; k*bitLength(len(t.values)) < len(t.values) {
+		for i := l; i <= r; i++ {
+			t.Update(i, value)
+		}
+		return
+	}
+	// 更新的元素较多时直接重建
+	for i := l; i <= r; i++ {
+		t.values[i] = value
+	}
+	t.build(t.values)
+}
+
+func (t *Tree) Empty() bool {
+	return len(t.values) == 0
+}
+
+func (t *Tree) Size() int {
+	return len(t.values)
+}
+
+// Clear removes all the elements.
+func (t *Tree) Clear() {
+	t.build(nil)
+}
+
+// Values returns the elements in index order.
+func (t *Tree) Values() []interface{} {
+	values := make([]interface{}, len(t.values))
+	copy(values, t.values)
+	return values
+}
+
+func (t *Tree) String() string {
+	str := "FenwickTree\n"
+	values := []string{}
+	for _, value := range t.values {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+func (t *Tree) checkIndex(i int) {
+	if i < 0 || i >= len(t.values) {
+		panic(fmt.Sprintf("Index out of range, %d not in [0, %d)", i, len(t.values)))
+	}
+}
+
+// build在O(n)内建树: 每个节点把自己的组合值加到父节点i+lowbit(i)上
+func (t *Tree) build(values []interface{}) {
+	t.values = make([]interface{}, len(values))
+	copy(t.values, values)
+	t.nodes = make([]interface{}, len(values)+1)
+	for i := 1; i <= len(values); i++ {
+		t.nodes[i] = values[i-1]
+	}
+	for i := 1; i <= len(values); i++ {
+		if parent := i + i&-i; parent <= len(values) {
+			t.nodes[parent] = t.Monoid.Combine(t.nodes[i], t.nodes[parent])
+		}
+	}
+}
+
+func bitLength(n int) int {
+	length := 0
+	for ; n > 0; n >>= 1 {
+		length++
+	}
+	return length
+}
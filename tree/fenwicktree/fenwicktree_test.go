@@ -0,0 +1,160 @@
+package fenwicktree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func TestFenwickTreeQuery(t *testing.T) {
+	tree := NewWithIntSum([]int{5, 3, 8, 1, 9, 7})
+
+	tests := [][]interface{}{
+		{0, 5, 33},
+		{0, 0, 5},
+		{1, 2, 11},
+		{2, 4, 18},
+		{5, 5, 7},
+		{3, 2, 0},
+	}
+	for _, test := range tests {
+		if actualValue := tree.Query(test[0].(int), test[1].(int)); actualValue != test[2] {
+			t.Errorf("Got %v expected %v", actualValue, test[2])
+		}
+	}
+	if actualValue := tree.Prefix(3); actualValue != 17 {
+		t.Errorf("Got %v expected %v", actualValue, 17)
+	}
+	if actualValue := tree.Prefix(-1); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestFenwickTreeUpdate(t *testing.T) {
+	tree := NewWithIntSum([]int{1, 2, 3, 4, 5, 6, 7, 8})
+
+	tree.Update(3, 10)
+	if actualValue := tree.Query(0, 7); actualValue != 42 {
+		t.Errorf("Got %v expected %v", actualValue, 42)
+	}
+	tree.RangeUpdate(2, 6, 0)
+	if actualValue := tree.Query(0, 7); actualValue != 11 {
+		t.Errorf("Got %v expected %v", actualValue, 11)
+	}
+	tree.RangeUpdate(0, 0, 2)
+	if actualValue := tree.Query(0, 1); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if actualValue := fmt.Sprint(tree.Values()); actualValue != "[2 2 0 0 0 0 0 8]" {
+		t.Errorf("Got %v expected %v", actualValue, "[2 2 0 0 0 0 0 8]")
+	}
+	if actualValue := tree.Get(7); actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	if actualValue := tree.String(); actualValue != "FenwickTree\n2, 2, 0, 0, 0, 0, 0, 8" {
+		t.Errorf("Got %v expected %v", actualValue, "FenwickTree\n2, 2, 0, 0, 0, 0, 0, 8")
+	}
+
+	tree.Clear()
+	if actualValue := tree.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := tree.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestFenwickTreeWithoutInverse(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got %v expected %v", r, "panic")
+		}
+	}()
+	NewWith(util.IntMinMonoid, []interface{}{1, 2, 3})
+}
+
+func TestFenwickTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	expected := make([]int, 300)
+	for i := range expected {
+		expected[i] = r.Intn(100)
+	}
+	tree := NewWithIntSum(expected)
+
+	for i := 0; i < 2000; i++ {
+		l := r.Intn(len(expected))
+		h := l + r.Intn(len(expected)-l)
+		switch r.Intn(3) {
+		case 0:
+			value := r.Intn(100)
+			for j := l; j <= h; j++ {
+				expected[j] = value
+			}
+			tree.RangeUpdate(l, h, value)
+		case 1:
+			expected[l] = r.Intn(100)
+			tree.Update(l, expected[l])
+		default:
+			expectedValue := 0
+			for j := l; j <= h; j++ {
+				expectedValue += expected[j]
+			}
+			if actualValue := tree.Query(l, h); actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), fmt.Sprint(expected); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func benchmarkQuery(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Query(n/2, n)
+		}
+	}
+}
+
+func benchmarkUpdate(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Update(n, n)
+		}
+	}
+}
+
+func BenchmarkFenwickTreeQuery1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewWithIntSum(make([]int, size))
+	b.StartTimer()
+	benchmarkQuery(b, tree, size)
+}
+
+func BenchmarkFenwickTreeQuery100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntSum(make([]int, size))
+	b.StartTimer()
+	benchmarkQuery(b, tree, size)
+}
+
+func BenchmarkFenwickTreeUpdate1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewWithIntSum(make([]int, size))
+	b.StartTimer()
+	benchmarkUpdate(b, tree, size)
+}
+
+func BenchmarkFenwickTreeUpdate100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntSum(make([]int, size))
+	b.StartTimer()
+	benchmarkUpdate(b, tree, size)
+}
@@ -0,0 +1,396 @@
+// Package fibonacciheap implements a Fibonacci heap. The operations keep the names of the other heaps:
+// PushHandle is insert, Pop is extract-min, Meld is union and Remove is delete.
+package fibonacciheap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/tree/internal/heapowner"
+	"github.com/morganxf/algorithm/util"
+)
+
+func assertHeapImplementation() {
+	var _ container.Container = (*Heap)(nil)
+}
+
+// Heap is a Fibonacci heap. Push, Meld and DecreaseKey cost O(1) amortized, Pop and Remove O(log n) amortized,
+// which makes it suitable for Dijkstra's and Prim's algorithms on dense graphs.
+type Heap struct {
+	min        *Handle // 根链表中最小的节点
+	size       int
+	owner      *heapowner.Owner // 第一次PushHandle或Meld时创建
+	Comparator util.Comparator
+}
+
+// Handle refers to a value pushed into a Heap, it is also the node of the heap.
+type Handle struct {
+	value  interface{}
+	parent *Handle
+	child  *Handle // 孩子组成的循环双向链表中的任意一个
+	left   *Handle
+	right  *Handle
+	degree int
+	mark   bool             // 成为孩子之后是否失去过孩子
+	owner  *heapowner.Owner // nil表示已经不在堆中
+}
+
+// Value returns the current value of the handle.
+func (h *Handle) Value() interface{} {
+	return h.value
+}
+
+func NewWith(comparator util.Comparator) *Heap {
+	return &Heap{Comparator: comparator}
+}
+
+func NewWithIntComparator() *Heap {
+	return NewWith(util.IntComparator)
+}
+
+func NewWithStringComparator() *Heap {
+	return NewWith(util.StringComparator)
+}
+
+func (heap *Heap) Push(values ...interface{}) {
+	for _, value := range values {
+		heap.PushHandle(value)
+	}
+}
+
+// PushHandle adds value to the heap and returns its handle for DecreaseKey and Remove.
+func (heap *Heap) PushHandle(value interface{}) *Handle {
+	handle := &Handle{value: value, owner: heap.init()}
+	handle.left, handle.right = handle, handle // 自成一个循环链表
+	heap.addRoot(handle)
+	heap.size++
+	return handle
+}
+
+func (heap *Heap) Pop() (interface{}, bool) {
+	if heap.min == nil {
+		return nil, false
+	}
+	min := heap.min
+	heap.extractMin()
+	return min.value, true
+}
+
+func (heap *Heap) Peek() (interface{}, bool) {
+	if heap.min == nil {
+		return nil, false
+	}
+	return heap.min.value, true
+}
+
+// PeekHandle returns the handle of the top value.
+func (heap *Heap) PeekHandle() (*Handle, bool) {
+	if heap.min == nil {
+		return nil, false
+	}
+	return heap.min, true
+}
+
+// Contains reports whether handle is in this heap, either pushed into it or melded into it from another heap.
+func (heap *Heap) Contains(handle *Handle) bool {
+	return handle != nil && heap.owner.Owns(handle.owner)
+}
+
+// DecreaseKey replaces the value of handle with a value that is not greater than it, in O(1) amortized.
+// It returns false if the heap does not contain handle and panics if value is greater than the current value.
+func (heap *Heap) DecreaseKey(handle *Handle, value interface{}) bool {
+	if !heap.Contains(handle) {
+		return false
+	}
+	if heap.Comparator(value, handle.value) > 0 {
+		panic("Invalid key, greater than the current value")
+	}
+	handle.value = value
+	if parent := handle.parent; parent != nil && heap.less(handle, parent) {
+		heap.cut(handle)
+		heap.cascadingCut(parent)
+	}
+	if heap.less(handle, heap.min) {
+		heap.min = handle
+	}
+	return true
+}
+
+// Remove deletes the value of handle from the heap in O(log n) amortized.
+// It returns false if the heap does not contain handle.
+func (heap *Heap) Remove(handle *Handle) bool {
+	if !heap.Contains(handle) {
+		return false
+	}
+	// 相当于把值减小到负无穷再弹出
+	if parent := handle.parent; parent != nil {
+		heap.cut(handle)
+		heap.cascadingCut(parent)
+	}
+	heap.min = handle
+	heap.extractMin()
+	return true
+}
+
+// Meld moves all the values of other into the heap in O(1), leaving other empty.
+// The handles of other stay valid and belong to the heap afterwards.
+func (heap *Heap) Meld(other *Heap) {
+	if other == heap || other.min == nil {
+		return
+	}
+	if heap.min == nil {
+		heap.min = other.min
+	} else {
+		splice(heap.min, other.min)
+		if heap.less(other.min, heap.min) {
+			heap.min = other.min
+		}
+	}
+	heap.size += other.size
+	other.owner.Meld(heap.init())
+	other.min, other.size, other.owner = nil, 0, nil
+}
+
+func (heap *Heap) Empty() bool {
+	return heap.size == 0
+}
+
+func (heap *Heap) Size() int {
+	return heap.size
+}
+
+// Clear removes all the values, the handles of the heap are no longer contained in it.
+func (heap *Heap) Clear() {
+	heap.min = nil
+	heap.size = 0
+	heap.owner = nil
+}
+
+// init创建零值堆的owner
+func (heap *Heap) init() *heapowner.Owner {
+	if heap.owner == nil {
+		heap.owner = heapowner.New()
+	}
+	return heap.owner
+}
+
+// Values returns the values in no particular order, the top value first.
+func (heap *Heap) Values() []interface{} {
+	values := make([]interface{}, 0, heap.size)
+	var walk func(first *Handle)
+	walk = func(first *Handle) {
+		if first == nil {
+			return
+		}
+		cur := first
+		for {
+			values = append(values, cur.value)
+			walk(cur.child)
+			if cur = cur.right; cur == first {
+				return
+			}
+		}
+	}
+	walk(heap.min)
+	return values
+}
+
+func (heap *Heap) String() string {
+	str := "FibonacciHeap\n"
+	values := []string{}
+	for _, value := range heap.Values() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// Validate checks the structure of the heap: the links of every list, the heap order, the degrees,
+// the marks of the roots, the minimum and the size. It returns the first violation found, it is meant for tests.
+func (heap *Heap) Validate() error {
+	size := 0
+	var check func(first *Handle, parent *Handle) error
+	check = func(first *Handle, parent *Handle) error {
+		cur := first
+		for {
+			size++
+			if cur.right.left != cur || cur.left.right != cur {
+				return fmt.Errorf("broken sibling links at %v", cur.value)
+			}
+			if cur.parent != parent {
+				return fmt.Errorf("wrong parent of %v", cur.value)
+			}
+			if cur.owner == nil {
+				return fmt.Errorf("node %v is marked as removed", cur.value)
+			}
+			if parent == nil && cur.mark {
+				return fmt.Errorf("root %v is marked", cur.value)
+			}
+			if parent != nil && heap.less(cur, parent) {
+				return fmt.Errorf("child %v is less than its parent %v", cur.value, parent.value)
+			}
+			if parent == nil && heap.less(cur, heap.min) {
+				return fmt.Errorf("root %v is less than the minimum %v", cur.value, heap.min.value)
+			}
+			degree := 0
+			if cur.child != nil {
+				for child := cur.child; ; {
+					degree++
+					if child = child.right; child == cur.child {
+						break
+					}
+				}
+				if err := check(cur.child, cur); err != nil {
+					return err
+				}
+			}
+			if degree != cur.degree {
+				return fmt.Errorf("node %v has degree %d but %d children", cur.value, cur.degree, degree)
+			}
+			if cur = cur.right; cur == first {
+				return nil
+			}
+		}
+	}
+	if heap.min != nil {
+		if err := check(heap.min, nil); err != nil {
+			return err
+		}
+	}
+	if size != heap.size {
+		return fmt.Errorf("heap has size %d but %d nodes", heap.size, size)
+	}
+	return nil
+}
+
+func (heap *Heap) less(a *Handle, b *Handle) bool {
+	return heap.Comparator(a.value, b.value) < 0
+}
+
+// addRoot把自成链表的单个节点加入根链表
+func (heap *Heap) addRoot(handle *Handle) {
+	handle.parent = nil
+	handle.mark = false
+	if heap.min == nil {
+		heap.min = handle
+		return
+	}
+	splice(heap.min, handle)
+	if heap.less(handle, heap.min) {
+		heap.min = handle
+	}
+}
+
+// extractMin删除heap.min: 它的孩子成为根, 再合并度数相同的根
+func (heap *Heap) extractMin() {
+	min := heap.min
+	if child := min.child; child != nil {
+		for cur := child; ; {
+			cur.parent = nil
+			cur.mark = false
+			if cur = cur.right; cur == child {
+				break
+			}
+		}
+		splice(min, child)
+	}
+	next := min.right
+	unlink(min)
+	min.child, min.parent, min.degree, min.mark, min.owner = nil, nil, 0, false, nil
+	heap.size--
+	if next == min {
+		heap.min = nil
+		return
+	}
+	heap.min = next
+	heap.consolidate()
+}
+
+// consolidate链接度数相同的根, 直到所有根的度数都不同, 并重新找到最小的根
+func (heap *Heap) consolidate() {
+	roots := []*Handle{}
+	for cur := heap.min; ; {
+		roots = append(roots, cur)
+		if cur = cur.right; cur == heap.min {
+			break
+		}
+	}
+	byDegree := []*Handle{}
+	for _, root := range roots {
+		for {
+			for root.degree >= len(byDegree) {
+				byDegree = append(byDegree, nil)
+			}
+			other := byDegree[root.degree]
+			if other == nil {
+				break
+			}
+			byDegree[root.degree] = nil
+			if heap.less(other, root) {
+				root, other = other, root
+			}
+			heap.link(other, root)
+		}
+		byDegree[root.degree] = root
+	}
+	heap.min = nil
+	for _, root := range byDegree {
+		if root != nil && (heap.min == nil || heap.less(root, heap.min)) {
+			heap.min = root
+		}
+	}
+}
+
+// link把根child链接为根parent的孩子
+func (heap *Heap) link(child *Handle, parent *Handle) {
+	unlink(child)
+	child.parent = parent
+	child.mark = false
+	if parent.child == nil {
+		parent.child = child
+	} else {
+		splice(parent.child, child)
+	}
+	parent.degree++
+}
+
+// cut把handle从父亲处剪下, 加入根链表
+func (heap *Heap) cut(handle *Handle) {
+	parent := handle.parent
+	if handle.right == handle {
+		parent.child = nil
+	} else if parent.child == handle {
+		parent.child = handle.right
+	}
+	unlink(handle)
+	parent.degree--
+	heap.addRoot(handle)
+}
+
+// cascadingCut: 非根节点第二次失去孩子时也被剪下, 保证度数为O(log n)
+func (heap *Heap) cascadingCut(handle *Handle) {
+	for parent := handle.parent; parent != nil; handle, parent = parent, parent.parent {
+		if !handle.mark {
+			handle.mark = true
+			return
+		}
+		heap.cut(handle)
+	}
+}
+
+// splice把以b所在的循环链表接到a之后
+func splice(a *Handle, b *Handle) {
+	aRight, bLeft := a.right, b.left
+	a.right = b
+	b.left = a
+	bLeft.right = aRight
+	aRight.left = bLeft
+}
+
+// unlink把handle从所在的循环链表中删除, handle自成一个链表
+func unlink(handle *Handle) {
+	handle.left.right = handle.right
+	handle.right.left = handle.left
+	handle.left, handle.right = handle, handle
+}
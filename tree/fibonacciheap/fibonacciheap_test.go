@@ -0,0 +1,458 @@
+package fibonacciheap
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func TestFibonacciHeapPushAndPop(t *testing.T) {
+	heap := NewWithIntComparator()
+
+	if actualValue, ok := heap.Pop(); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue, ok := heap.Peek(); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	heap.Push(3)
+	heap.Push(5, 1, 4)
+	handle := heap.PushHandle(2)
+
+	// Push是惰性的: 每个值都是一个度数为0的根, Pop时才合并
+	if actualValue := rootDegrees(heap); !equalInts(actualValue, []int{0, 0, 0, 0, 0}) {
+		t.Errorf("Got %v expected %v", actualValue, []int{0, 0, 0, 0, 0})
+	}
+	if actualValue, ok := heap.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	for _, expectedValue := range []int{1, 2, 3, 4, 5} {
+		if actualValue, ok := heap.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if err := heap.Validate(); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+	if actualValue := heap.Contains(handle); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestFibonacciHeapConsolidate(t *testing.T) {
+	heap := NewWithIntComparator()
+	for i := 0; i < 9; i++ {
+		heap.Push(i)
+	}
+	// 弹出0之后剩下的8个度数为0的根合并成一棵度数为3的二项树
+	heap.Pop()
+	if actualValue := rootDegrees(heap); !equalInts(actualValue, []int{3}) {
+		t.Errorf("Got %v expected %v", actualValue, []int{3})
+	}
+	heap.Pop()
+	if actualValue := rootDegrees(heap); !equalInts(distinct(actualValue), actualValue) {
+		t.Errorf("Got %v expected distinct degrees", actualValue)
+	}
+	if err := heap.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+}
+
+func TestFibonacciHeapCascadingCut(t *testing.T) {
+	heap := NewWithIntComparator()
+	handles := []*Handle{}
+	for i := 0; i < 9; i++ {
+		handles = append(handles, heap.PushHandle(i*10))
+	}
+	heap.Pop()
+
+	// 找到根下面度数为2的孩子, 依次剪下它的两个孩子
+	var parent *Handle
+	for child := heap.min.child; parent == nil; child = child.right {
+		if child.degree == 2 {
+			parent = child
+		}
+	}
+	first, second := parent.child, parent.child.right
+	heap.DecreaseKey(first, -1)
+	if actualValue := first.parent; actualValue != nil {
+		t.Errorf("Got %v expected %v", actualValue.value, nil)
+	}
+	if actualValue := parent.mark; actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := parent.degree; actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	// 第二次失去孩子的节点也被剪下成为根, 标记被清除
+	heap.DecreaseKey(second, -2)
+	if actualValue := parent.parent; actualValue != nil {
+		t.Errorf("Got %v expected %v", actualValue.value, nil)
+	}
+	if actualValue := parent.mark; actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := heap.Size(); actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	if actualValue := len(rootDegrees(heap)); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if err := heap.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if top, ok := heap.PeekHandle(); top != second || !ok {
+		t.Errorf("Got %v expected %v", top.value, second.value)
+	}
+}
+
+func TestFibonacciHeapDecreaseKeyAndRemove(t *testing.T) {
+	heap := NewWithIntComparator()
+	handles := []*Handle{}
+	for _, value := range []int{10, 20, 30, 40, 50} {
+		handles = append(handles, heap.PushHandle(value))
+	}
+	heap.Pop()
+
+	// 根的值减小时只更新最小值, 不改变结构
+	roots := rootDegrees(heap)
+	if actualValue := heap.DecreaseKey(heap.min, 15); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := rootDegrees(heap); !equalInts(actualValue, roots) {
+		t.Errorf("Got %v expected %v", actualValue, roots)
+	}
+	if actualValue := heap.DecreaseKey(handles[3], 5); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if top, ok := heap.PeekHandle(); top != handles[3] || !ok {
+		t.Errorf("Got %v expected %v", top.value, handles[3].value)
+	}
+	if actualValue := heap.DecreaseKey(handles[0], 1); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := heap.Remove(handles[2]); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.Remove(handles[2]); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if err := heap.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	for _, expectedValue := range []int{5, 15, 50} {
+		if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	handle := heap.PushHandle(1)
+	heap.Clear()
+	if actualValue := heap.Contains(handle); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestFibonacciHeapDecreaseKeyInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got %v expected %v", r, "panic")
+		}
+	}()
+	heap := NewWithIntComparator()
+	heap.DecreaseKey(heap.PushHandle(1), 2)
+}
+
+func TestFibonacciHeapMeld(t *testing.T) {
+	heap := NewWithIntComparator()
+	other := NewWithIntComparator()
+	heap.Push(4, 8)
+	handle := other.PushHandle(6)
+	other.Push(2)
+
+	// Meld只拼接两个根链表, 不做合并
+	heap.Meld(other)
+	if actualValue := rootDegrees(heap); !equalInts(actualValue, []int{0, 0, 0, 0}) {
+		t.Errorf("Got %v expected %v", actualValue, []int{0, 0, 0, 0})
+	}
+	if actualValue, _ := heap.Peek(); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue := other.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := other.Contains(handle); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if err := heap.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	heap.Meld(heap)
+	third := NewWithIntComparator()
+	third.Push(7)
+	third.Meld(heap)
+	if actualValue := third.Contains(handle); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	third.DecreaseKey(handle, 1)
+	for _, expectedValue := range []int{1, 2, 4, 7, 8} {
+		if actualValue, _ := third.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestFibonacciHeapZeroValue(t *testing.T) {
+	heap := &Heap{Comparator: util.IntComparator}
+	if actualValue := heap.Contains(&Handle{}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	handle := heap.PushHandle(3)
+	heap.Push(1, 2)
+	if actualValue := heap.DecreaseKey(handle, 0); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	removed := heap.PushHandle(4)
+	if actualValue := heap.Remove(removed); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	other := &Heap{Comparator: util.IntComparator}
+	other.Meld(heap)
+	heap.Meld(&Heap{Comparator: util.IntComparator})
+	if actualValue := other.Contains(handle); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	for _, expectedValue := range []int{0, 1, 2} {
+		if actualValue, ok := other.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue := other.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestFibonacciHeapRandom(t *testing.T) {
+	heaps := []*Heap{NewWithIntComparator(), NewWithIntComparator()}
+	r := rand.New(rand.NewSource(3))
+	handles := []*Handle{}
+	for i := 0; i < 2000; i++ {
+		handles = append(handles, heaps[i%2].PushHandle(r.Intn(1000)))
+	}
+	heap := heaps[0]
+	heap.Meld(heaps[1])
+	for i := 0; i < 1500; i++ {
+		if i%100 == 0 {
+			if err := heap.Validate(); err != nil {
+				t.Errorf("Got error %v", err)
+			}
+			// 级联剪切保证度数不超过log_phi(n)
+			if heap.Size() > 0 {
+				bound := int(math.Log(float64(heap.Size())) / math.Log(math.Phi))
+				if actualValue := maxDegree(heap.min); actualValue > bound {
+					t.Errorf("Got degree %v greater than %v", actualValue, bound)
+				}
+			}
+		}
+		handle := handles[r.Intn(len(handles))]
+		switch r.Intn(3) {
+		case 0:
+			if heap.Contains(handle) {
+				heap.DecreaseKey(handle, handle.Value().(int)-r.Intn(100))
+			}
+		case 1:
+			heap.Remove(handle)
+		default:
+			heap.Pop()
+		}
+	}
+	if err := heap.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	expected := []int{}
+	for _, handle := range handles {
+		if heap.Contains(handle) {
+			expected = append(expected, handle.Value().(int))
+		}
+	}
+	sort.Ints(expected)
+	if actualValue, expectedValue := heap.Size(), len(expected); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for _, expectedValue := range expected {
+		if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			break
+		}
+	}
+}
+
+func TestFibonacciHeapValidate(t *testing.T) {
+	heap := NewWithIntComparator()
+	handles := []*Handle{}
+	for i := 0; i < 10; i++ {
+		handles = append(handles, heap.PushHandle(i))
+	}
+	heap.Pop()
+	if err := heap.Validate(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	heap.size++
+	if err := heap.Validate(); err == nil {
+		t.Errorf("Got %v expected %v", err, "error")
+	}
+	heap.size--
+	handles[9].value = -1
+	if err := heap.Validate(); err == nil {
+		t.Errorf("Got %v expected %v", err, "error")
+	}
+}
+
+func TestFibonacciHeapDijkstra(t *testing.T) {
+	// 0 -> 1 (4), 0 -> 2 (1), 2 -> 1 (2), 1 -> 3 (1), 2 -> 3 (5)
+	edges := [][][2]int{{{1, 4}, {2, 1}}, {{3, 1}}, {{1, 2}, {3, 5}}, {}}
+	type vertex struct {
+		id, distance int
+	}
+	heap := NewWith(func(a, b interface{}) int {
+		return a.(vertex).distance - b.(vertex).distance
+	})
+	handles := make([]*Handle, len(edges))
+	handles[0] = heap.PushHandle(vertex{0, 0})
+	distances := map[int]int{}
+	for !heap.Empty() {
+		value, _ := heap.Pop()
+		u := value.(vertex)
+		distances[u.id] = u.distance
+		for _, edge := range edges[u.id] {
+			v, distance := edge[0], u.distance+edge[1]
+			switch {
+			case handles[v] == nil:
+				handles[v] = heap.PushHandle(vertex{v, distance})
+			case heap.Contains(handles[v]) && distance < handles[v].Value().(vertex).distance:
+				heap.DecreaseKey(handles[v], vertex{v, distance})
+			}
+		}
+	}
+	for v, expectedValue := range []int{0, 3, 1, 4} {
+		if actualValue := distances[v]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+// rootDegrees返回根链表中各个根的度数, 从最小的根开始
+func rootDegrees(heap *Heap) []int {
+	result := []int{}
+	if heap.min == nil {
+		return result
+	}
+	for cur := heap.min; ; {
+		result = append(result, cur.degree)
+		if cur = cur.right; cur == heap.min {
+			return result
+		}
+	}
+}
+
+func maxDegree(first *Handle) int {
+	result := 0
+	for cur := first; ; {
+		if cur.degree > result {
+			result = cur.degree
+		}
+		if cur.child != nil {
+			if degree := maxDegree(cur.child); degree > result {
+				result = degree
+			}
+		}
+		if cur = cur.right; cur == first {
+			return result
+		}
+	}
+}
+
+func distinct(values []int) []int {
+	seen := map[int]bool{}
+	result := []int{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func equalInts(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func benchmarkPush(b *testing.B, heap *Heap, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			heap.Push(n)
+		}
+	}
+}
+
+func benchmarkPop(b *testing.B, heap *Heap, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			heap.Pop()
+		}
+	}
+}
+
+func BenchmarkFibonacciHeapPush1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	heap := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkFibonacciHeapPush100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	heap := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkFibonacciHeapPop1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	heap := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPop(b, heap, size)
+}
+
+func BenchmarkFibonacciHeapPop100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	heap := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPop(b, heap, size)
+}
@@ -0,0 +1,291 @@
+// Package btreecore implements the insert and delete algorithms of a B-tree once, for the in-memory btree.Tree
+// and for trees whose nodes live elsewhere, such as the pages of pagedbtree.
+package btreecore
+
+import "github.com/morganxf/algorithm/util"
+
+// Nodes gives the algorithms access to the nodes of a tree. N refers to a loaded node, C is what a node keeps
+// for each of its children, the child itself in memory or a page number on disk, and E is an entry.
+// The algorithms modify the slices returned by Entries and Children in place and always pass them back
+// to SetEntries or SetChildren, which is where an implementation notices the change.
+type Nodes[N any, C any, E any] interface {
+	Key(e E) interface{}
+	Entries(n N) []E
+	SetEntries(n N, entries []E)
+	Children(n N) []C
+	SetChildren(n N, children []C)
+	// Child loads the index-th child of n.
+	Child(n N, index int) (N, error)
+	// Ref returns what a parent keeps for n.
+	Ref(n N) C
+	NewNode() (N, error)
+	// Free is called for a node that was merged into its sibling, or for a root that no longer has entries.
+	Free(n N) error
+}
+
+// Tree runs the algorithms with M as the maximum number of children of a node.
+type Tree[N any, C any, E any] struct {
+	Nodes      Nodes[N, C, E]
+	Comparator util.Comparator
+	M          int
+}
+
+func (t Tree[N, C, E]) maxEntries() int {
+	return t.M - 1
+}
+
+func (t Tree[N, C, E]) minEntries() int {
+	// ceil(m/2)-1
+	return (t.M+1)/2 - 1
+}
+
+func (t Tree[N, C, E]) middle() int {
+	return (t.M - 1) / 2
+}
+
+func (t Tree[N, C, E]) isLeaf(n N) bool {
+	return len(t.Nodes.Children(n)) == 0
+}
+
+// Search returns the index of key in the entries of n, or the index at which it would be inserted.
+func (t Tree[N, C, E]) Search(n N, key interface{}) (index int, found bool) {
+	entries := t.Nodes.Entries(n)
+	low, high := 0, len(entries)-1
+	// 当low==high时, key>entries[low]返回low+1, key<entries[low]返回low
+	for low <= high {
+		mid := (low + high) / 2
+		compare := t.Comparator(key, t.Nodes.Key(entries[mid]))
+		switch {
+		case compare > 0:
+			low = mid + 1
+		case compare < 0:
+			high = mid - 1
+		default:
+			return mid, true
+		}
+	}
+	return low, false
+}
+
+// Insert puts e into the tree rooted at root, replacing the entry with an equal key if there is one.
+// It returns the root afterwards, a new one if root was split, and whether the number of entries grew.
+func (t Tree[N, C, E]) Insert(root N, e E) (N, bool, error) {
+	inserted, err := t.insert(root, e)
+	if err != nil || len(t.Nodes.Entries(root)) <= t.maxEntries() {
+		return root, inserted, err
+	}
+	// split root，树的高度增加
+	right, middle, err := t.split(root)
+	if err != nil {
+		return root, false, err
+	}
+	newRoot, err := t.Nodes.NewNode()
+	if err != nil {
+		return root, false, err
+	}
+	t.Nodes.SetEntries(newRoot, []E{middle})
+	t.Nodes.SetChildren(newRoot, []C{t.Nodes.Ref(root), t.Nodes.Ref(right)})
+	return newRoot, inserted, nil
+}
+
+// Delete removes the entry with the given key from the tree rooted at root and returns the root afterwards:
+// a child of root if root lost its last entry, or the zero N if the tree is empty.
+func (t Tree[N, C, E]) Delete(root N, key interface{}) (N, bool, error) {
+	removed, err := t.delete(root, key)
+	if err != nil || len(t.Nodes.Entries(root)) > 0 {
+		return root, removed, err
+	}
+	// root的entry为空，树的高度降低
+	var newRoot N
+	if !t.isLeaf(root) {
+		if newRoot, err = t.Nodes.Child(root, 0); err != nil {
+			return root, false, err
+		}
+	}
+	return newRoot, removed, t.Nodes.Free(root)
+}
+
+// insert只能在叶子节点插入, 孩子的entry数目超过上限时由parent在递归返回时split
+func (t Tree[N, C, E]) insert(n N, e E) (bool, error) {
+	index, found := t.Search(n, t.Nodes.Key(e))
+	if found {
+		entries := t.Nodes.Entries(n)
+		entries[index] = e
+		t.Nodes.SetEntries(n, entries)
+		return false, nil
+	}
+	if t.isLeaf(n) {
+		t.Nodes.SetEntries(n, insertAt(t.Nodes.Entries(n), index, e))
+		return true, nil
+	}
+	child, err := t.Nodes.Child(n, index)
+	if err != nil {
+		return false, err
+	}
+	inserted, err := t.insert(child, e)
+	if err != nil || len(t.Nodes.Entries(child)) <= t.maxEntries() {
+		return inserted, err
+	}
+	right, middle, err := t.split(child)
+	if err != nil {
+		return false, err
+	}
+	// middle entry插入到parent, right作为child右侧的新孩子
+	t.Nodes.SetEntries(n, insertAt(t.Nodes.Entries(n), index, middle))
+	t.Nodes.SetChildren(n, insertAt(t.Nodes.Children(n), index+1, t.Nodes.Ref(right)))
+	return inserted, nil
+}
+
+// split以middle为中间把n一分为二，n保留左半部分，返回右半部分和middle entry
+func (t Tree[N, C, E]) split(n N) (N, E, error) {
+	middle := t.middle()
+	right, err := t.Nodes.NewNode()
+	if err != nil {
+		var e E
+		return right, e, err
+	}
+	entries := t.Nodes.Entries(n)
+	t.Nodes.SetEntries(right, append([]E(nil), entries[middle+1:]...))
+	t.Nodes.SetEntries(n, append([]E(nil), entries[:middle]...))
+	if children := t.Nodes.Children(n); len(children) > 0 {
+		t.Nodes.SetChildren(right, append([]C(nil), children[middle+1:]...))
+		t.Nodes.SetChildren(n, append([]C(nil), children[:middle+1]...))
+	}
+	return right, entries[middle], nil
+}
+
+// delete从以n为根的子树中删除key, 孩子的entry数目不足时由parent在递归返回时rebalance
+func (t Tree[N, C, E]) delete(n N, key interface{}) (bool, error) {
+	index, found := t.Search(n, key)
+	if t.isLeaf(n) {
+		if found {
+			t.Nodes.SetEntries(n, removeAt(t.Nodes.Entries(n), index))
+		}
+		return found, nil
+	}
+	child, err := t.Nodes.Child(n, index)
+	if err != nil {
+		return false, err
+	}
+	if found {
+		// 用左孩子子树中最大的entry替换被删除的entry
+		max, err := t.deleteMax(child)
+		if err != nil {
+			return false, err
+		}
+		entries := t.Nodes.Entries(n)
+		entries[index] = max
+		t.Nodes.SetEntries(n, entries)
+	} else if removed, err := t.delete(child, key); err != nil || !removed {
+		return false, err
+	}
+	return true, t.rebalance(n, index, child)
+}
+
+// deleteMax删除以n为根的子树中最大的entry并返回它
+func (t Tree[N, C, E]) deleteMax(n N) (E, error) {
+	if t.isLeaf(n) {
+		entries := t.Nodes.Entries(n)
+		max := entries[len(entries)-1]
+		t.Nodes.SetEntries(n, removeAt(entries, len(entries)-1))
+		return max, nil
+	}
+	index := len(t.Nodes.Children(n)) - 1
+	child, err := t.Nodes.Child(n, index)
+	if err != nil {
+		var e E
+		return e, err
+	}
+	max, err := t.deleteMax(child)
+	if err != nil {
+		return max, err
+	}
+	return max, t.rebalance(n, index, child)
+}
+
+// rebalance在parent的第index个孩子n的entry数目不足时，先从左兄弟借，再从右兄弟借，否则与右兄弟合并，没有右兄弟时与左兄弟合并
+func (t Tree[N, C, E]) rebalance(parent N, index int, n N) error {
+	if len(t.Nodes.Entries(n)) >= t.minEntries() {
+		return nil
+	}
+	var left, right N
+	var err error
+	hasLeft, hasRight := index > 0, index+1 < len(t.Nodes.Children(parent))
+	if hasLeft {
+		if left, err = t.Nodes.Child(parent, index-1); err != nil {
+			return err
+		}
+	}
+	if hasRight {
+		if right, err = t.Nodes.Child(parent, index+1); err != nil {
+			return err
+		}
+	}
+	parentEntries, entries := t.Nodes.Entries(parent), t.Nodes.Entries(n)
+
+	// 从左兄弟借entry: parent的entry下放，左兄弟的最右entry提升
+	if hasLeft && len(t.Nodes.Entries(left)) > t.minEntries() {
+		leftEntries := t.Nodes.Entries(left)
+		t.Nodes.SetEntries(n, insertAt(entries, 0, parentEntries[index-1]))
+		parentEntries[index-1] = leftEntries[len(leftEntries)-1]
+		t.Nodes.SetEntries(parent, parentEntries)
+		t.Nodes.SetEntries(left, removeAt(leftEntries, len(leftEntries)-1))
+		// 左兄弟的最右孩子成为n的最左孩子
+		if leftChildren := t.Nodes.Children(left); len(leftChildren) > 0 {
+			t.Nodes.SetChildren(n, insertAt(t.Nodes.Children(n), 0, leftChildren[len(leftChildren)-1]))
+			t.Nodes.SetChildren(left, removeAt(leftChildren, len(leftChildren)-1))
+		}
+		// parent的entry数目没有变化
+		return nil
+	}
+	// 从右兄弟借entry: parent的entry下放，右兄弟的最左entry提升
+	if hasRight && len(t.Nodes.Entries(right)) > t.minEntries() {
+		rightEntries := t.Nodes.Entries(right)
+		t.Nodes.SetEntries(n, append(entries, parentEntries[index]))
+		parentEntries[index] = rightEntries[0]
+		t.Nodes.SetEntries(parent, parentEntries)
+		t.Nodes.SetEntries(right, removeAt(rightEntries, 0))
+		if rightChildren := t.Nodes.Children(right); len(rightChildren) > 0 {
+			t.Nodes.SetChildren(n, append(t.Nodes.Children(n), rightChildren[0]))
+			t.Nodes.SetChildren(right, removeAt(rightChildren, 0))
+		}
+		return nil
+	}
+	// 合并，以parent对应的entry作为中间连接, parent的entry数目减少，由parent的parent在递归返回时rebalance
+	if hasRight {
+		return t.merge(parent, index, n, right)
+	}
+	if hasLeft {
+		return t.merge(parent, index-1, left, n)
+	}
+	return nil
+}
+
+// merge把right和parent的第index个entry合并到left中, left和right为parent的第index和index+1个孩子
+func (t Tree[N, C, E]) merge(parent N, index int, left N, right N) error {
+	parentEntries := t.Nodes.Entries(parent)
+	t.Nodes.SetEntries(left, append(append(t.Nodes.Entries(left), parentEntries[index]), t.Nodes.Entries(right)...))
+	if rightChildren := t.Nodes.Children(right); len(rightChildren) > 0 {
+		t.Nodes.SetChildren(left, append(t.Nodes.Children(left), rightChildren...))
+	}
+	t.Nodes.SetEntries(parent, removeAt(parentEntries, index))
+	t.Nodes.SetChildren(parent, removeAt(t.Nodes.Children(parent), index+1))
+	return t.Nodes.Free(right)
+}
+
+// insertAt在s的index处插入value
+func insertAt[T any](s []T, index int, value T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[index+1:], s[index:])
+	s[index] = value
+	return s
+}
+
+// removeAt删除s的第index个元素, 并清空末尾的位置
+func removeAt[T any](s []T, index int) []T {
+	var zero T
+	copy(s[index:], s[index+1:])
+	s[len(s)-1] = zero
+	return s[:len(s)-1]
+}
@@ -0,0 +1,192 @@
+package btreecore
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+// testNode通过id引用孩子, 模拟不在内存中直接相连的node
+type testNode struct {
+	id       int
+	entries  []int
+	children []int
+}
+
+type testNodes struct {
+	nodes  map[int]*testNode
+	nextID int
+}
+
+func (s *testNodes) Key(e int) interface{} {
+	return e
+}
+
+func (s *testNodes) Entries(n *testNode) []int {
+	return n.entries
+}
+
+func (s *testNodes) SetEntries(n *testNode, entries []int) {
+	n.entries = entries
+}
+
+func (s *testNodes) Children(n *testNode) []int {
+	return n.children
+}
+
+func (s *testNodes) SetChildren(n *testNode, children []int) {
+	n.children = children
+}
+
+func (s *testNodes) Child(n *testNode, index int) (*testNode, error) {
+	return s.nodes[n.children[index]], nil
+}
+
+func (s *testNodes) Ref(n *testNode) int {
+	return n.id
+}
+
+func (s *testNodes) NewNode() (*testNode, error) {
+	s.nextID++
+	n := &testNode{id: s.nextID}
+	s.nodes[n.id] = n
+	return n, nil
+}
+
+func (s *testNodes) Free(n *testNode) error {
+	delete(s.nodes, n.id)
+	return nil
+}
+
+func newTestTree(m int) (Tree[*testNode, int, int], *testNodes) {
+	nodes := &testNodes{nodes: map[int]*testNode{}}
+	return Tree[*testNode, int, int]{Nodes: nodes, Comparator: util.IntComparator, M: m}, nodes
+}
+
+func TestTreeSplit(t *testing.T) {
+	tree, nodes := newTestTree(3)
+	root, _ := nodes.NewNode()
+	for _, e := range []int{1, 2, 3} {
+		root, _, _ = tree.Insert(root, e)
+	}
+	// 第三个entry使root分裂, middle entry成为新的root
+	if actualValue, expectedValue := root.entries, []int{2}; !equalInts(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	left, right := nodes.nodes[root.children[0]], nodes.nodes[root.children[1]]
+	if actualValue, expectedValue := left.entries, []int{1}; !equalInts(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := right.entries, []int{3}; !equalInts(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := len(nodes.nodes); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+
+	// 删除后两个孩子合并, root为空时由孩子代替, 被合并的node和旧的root都被Free
+	root, removed, _ := tree.Delete(root, 3)
+	if !removed {
+		t.Errorf("Got %v expected %v", removed, true)
+	}
+	if actualValue, expectedValue := root.entries, []int{1, 2}; !equalInts(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := len(nodes.nodes); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+}
+
+func TestTreeRandom(t *testing.T) {
+	for _, m := range []int{3, 4, 5, 8} {
+		tree, nodes := newTestTree(m)
+		root, _ := nodes.NewNode()
+		r := rand.New(rand.NewSource(int64(m)))
+		expected := map[int]bool{}
+		for i := 0; i < 3000; i++ {
+			key := r.Intn(500)
+			if r.Intn(3) == 0 {
+				if root == nil {
+					continue
+				}
+				var removed bool
+				root, removed, _ = tree.Delete(root, key)
+				if removed != expected[key] {
+					t.Errorf("Got %v expected %v", removed, expected[key])
+				}
+				delete(expected, key)
+			} else {
+				if root == nil {
+					root, _ = nodes.NewNode()
+				}
+				var inserted bool
+				root, inserted, _ = tree.Insert(root, key)
+				if inserted == expected[key] {
+					t.Errorf("Got %v expected %v", inserted, !expected[key])
+				}
+				expected[key] = true
+			}
+		}
+		assertValidTree(t, tree, nodes, root, expected)
+	}
+}
+
+// assertValidTree检查entry数目的上下限, 叶子的深度, 以及没有被Free的node都在树中
+func assertValidTree(t *testing.T, tree Tree[*testNode, int, int], nodes *testNodes, root *testNode, expected map[int]bool) {
+	keys := []int{}
+	reachable := 0
+	leafDepth := -1
+	var check func(n *testNode, depth int)
+	check = func(n *testNode, depth int) {
+		reachable++
+		if n != root && len(n.entries) < tree.minEntries() || len(n.entries) > tree.maxEntries() {
+			t.Errorf("Got %v entries in node %v", len(n.entries), n.id)
+		}
+		if tree.isLeaf(n) {
+			if leafDepth == -1 {
+				leafDepth = depth
+			} else if depth != leafDepth {
+				t.Errorf("Got leaf depth %v expected %v", depth, leafDepth)
+			}
+			keys = append(keys, n.entries...)
+			return
+		}
+		if len(n.children) != len(n.entries)+1 {
+			t.Errorf("Got %v children for %v entries", len(n.children), len(n.entries))
+		}
+		for i, id := range n.children {
+			check(nodes.nodes[id], depth+1)
+			if i < len(n.entries) {
+				keys = append(keys, n.entries[i])
+			}
+		}
+	}
+	if root != nil {
+		check(root, 0)
+	}
+	if actualValue, expectedValue := reachable, len(nodes.nodes); actualValue != expectedValue {
+		t.Errorf("Got %v reachable nodes expected %v", actualValue, expectedValue)
+	}
+	expectedKeys := []int{}
+	for key := range expected {
+		expectedKeys = append(expectedKeys, key)
+	}
+	sort.Ints(expectedKeys)
+	if !equalInts(keys, expectedKeys) {
+		t.Errorf("Got %v expected %v", keys, expectedKeys)
+	}
+}
+
+func equalInts(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
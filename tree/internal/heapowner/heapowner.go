@@ -0,0 +1,44 @@
+// Package heapowner tracks which heap the handles of a meldable heap belong to.
+package heapowner
+
+// Owner stands for a heap, every handle points to the Owner of the heap it was pushed into.
+// Meld points the Owner of the melded heap to the Owner of the receiving heap instead of visiting its handles,
+// Find follows these links and compresses the path, so a lookup costs nearly O(1) amortized.
+// A nil *Owner is valid: it owns no handles.
+type Owner struct {
+	next *Owner
+}
+
+// New returns the Owner of a new heap.
+func New() *Owner {
+	return &Owner{}
+}
+
+// Find returns the Owner that o has been melded into, o itself if it has not been melded.
+func (o *Owner) Find() *Owner {
+	root := o
+	for root.next != nil {
+		root = root.next
+	}
+	// 压缩路径
+	for o != root {
+		next := o.next
+		o.next = root
+		o = next
+	}
+	return root
+}
+
+// Owns reports whether a handle whose owner is handle belongs to the heap of o.
+// A nil handle owner stands for a handle removed from its heap.
+func (o *Owner) Owns(handle *Owner) bool {
+	return o != nil && handle != nil && handle.Find() == o
+}
+
+// Meld hands the handles of o over to into. o must not be used for a heap afterwards.
+func (o *Owner) Meld(into *Owner) {
+	if o == nil || o == into {
+		return
+	}
+	o.next = into
+}
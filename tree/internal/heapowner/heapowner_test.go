@@ -0,0 +1,36 @@
+package heapowner
+
+import "testing"
+
+func TestOwnerMeld(t *testing.T) {
+	a, b, c := New(), New(), New()
+	if actualValue := a.Owns(b); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	b.Meld(a)
+	c.Meld(b)
+	if actualValue := a.Owns(c); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := c.next; actualValue != a {
+		t.Errorf("Got %v expected %v", actualValue, a)
+	}
+	if actualValue := b.Owns(c); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	a.Meld(a)
+	if actualValue := a.Find(); actualValue != a {
+		t.Errorf("Got %v expected %v", actualValue, a)
+	}
+}
+
+func TestOwnerNil(t *testing.T) {
+	var o *Owner
+	if actualValue := o.Owns(New()); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := New().Owns(nil); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	o.Meld(New())
+}
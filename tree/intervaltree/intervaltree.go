@@ -0,0 +1,164 @@
+package intervaltree
+
+import (
+	"fmt"
+
+	"github.com/morganxf/algorithm/tree/avltree"
+	"github.com/morganxf/algorithm/util"
+)
+
+// Interval is the closed interval [Start, End].
+type Interval struct {
+	Start interface{}
+	End   interface{}
+}
+
+func (i Interval) String() string {
+	return fmt.Sprintf("[%v, %v]", i.Start, i.End)
+}
+
+// Tree is an interval tree: an AVL tree ordered by Start then End, whose nodes also hold the largest End
+// of their subtree. It is an avltree.Tree whose augment function maintains the largest End.
+type Tree struct {
+	tree       *avltree.Tree   // key为Interval, value为*entry
+	Comparator util.Comparator // 比较区间的端点
+}
+
+// entry是avltree中node的value
+type entry struct {
+	value interface{}
+	max   interface{} // 子树中最大的End
+}
+
+// NewWith returns an empty tree whose interval endpoints are ordered by comparator.
+func NewWith(comparator util.Comparator) *Tree {
+	return &Tree{Comparator: comparator}
+}
+
+func NewWithIntComparator() *Tree {
+	return NewWith(util.IntComparator)
+}
+
+func NewWithStringComparator() *Tree {
+	return NewWith(util.StringComparator)
+}
+
+// Insert adds the interval [start, end] with its value, replacing the value if the interval is already in the tree.
+// It panics if start is greater than end.
+func (t *Tree) Insert(start interface{}, end interface{}, value interface{}) {
+	if t.Comparator(start, end) > 0 {
+		panic("Invalid interval, start is greater than end")
+	}
+	t.avl().Put(Interval{Start: start, End: end}, &entry{value: value})
+}
+
+// Get returns the value of the interval [start, end].
+func (t *Tree) Get(start interface{}, end interface{}) (interface{}, bool) {
+	e, found := t.avl().Get(Interval{Start: start, End: end})
+	if !found {
+		return nil, false
+	}
+	return e.(*entry).value, true
+}
+
+// Delete removes the interval [start, end].
+func (t *Tree) Delete(start interface{}, end interface{}) {
+	t.avl().Remove(Interval{Start: start, End: end})
+}
+
+// Overlaps returns an iterator over the intervals containing point, in order.
+func (t *Tree) Overlaps(point interface{}) *OverlapIterator {
+	return t.OverlapsRange(point, point)
+}
+
+// OverlapsRange returns an iterator over the intervals overlapping [from, to], in order.
+// Each step skips the subtrees whose largest End is less than from, so listing k intervals is O((k+1) log n).
+func (t *Tree) OverlapsRange(from interface{}, to interface{}) *OverlapIterator {
+	return &OverlapIterator{tree: t, from: from, to: to, position: begin}
+}
+
+// MaxEnd returns the largest End of the intervals in the tree, found is false if the tree is empty.
+func (t *Tree) MaxEnd() (max interface{}, found bool) {
+	if root := t.avl().Root; root != nil {
+		return maxEnd(root), true
+	}
+	return nil, false
+}
+
+func (t *Tree) Empty() bool {
+	return t.avl().Empty()
+}
+
+func (t *Tree) Size() int {
+	return t.avl().Size()
+}
+
+// Intervals returns all the intervals ordered by Start then End.
+func (t *Tree) Intervals() []Interval {
+	it := t.Iterator()
+	intervals := make([]Interval, 0, t.Size())
+	for it.Next() {
+		intervals = append(intervals, it.Interval())
+	}
+	return intervals
+}
+
+func (t *Tree) Values() []interface{} {
+	it := t.Iterator()
+	values := make([]interface{}, 0, t.Size())
+	for it.Next() {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
+func (t *Tree) Clear() {
+	t.avl().Clear()
+}
+
+func (t *Tree) String() string {
+	// avltree的输出以Tree开头, 格式相同
+	return "Interval" + t.avl().String()
+}
+
+// avl返回保存区间的avltree, 零值的Tree在第一次使用时创建
+func (t *Tree) avl() *avltree.Tree {
+	if t.tree == nil {
+		t.tree = avltree.NewWithAugment(func(a, b interface{}) int {
+			return t.compare(a.(Interval), b.(Interval))
+		}, t.update)
+	}
+	return t.tree
+}
+
+// compare先比较Start再比较End
+func (t *Tree) compare(a Interval, b Interval) int {
+	if cmp := t.Comparator(a.Start, b.Start); cmp != 0 {
+		return cmp
+	}
+	return t.Comparator(a.End, b.End)
+}
+
+// overlaps判断n的区间是否与[from, to]相交
+func (t *Tree) overlaps(n *avltree.Node, from interface{}, to interface{}) bool {
+	return t.Comparator(interval(n).Start, to) <= 0 && t.Comparator(interval(n).End, from) >= 0
+}
+
+// update是avltree的augment, 根据孩子重新计算子树中最大的End
+func (t *Tree) update(n *avltree.Node) {
+	e := n.Value.(*entry)
+	e.max = interval(n).End
+	for _, child := range n.Children {
+		if child != nil && t.Comparator(maxEnd(child), e.max) > 0 {
+			e.max = maxEnd(child)
+		}
+	}
+}
+
+func interval(n *avltree.Node) Interval {
+	return n.Key.(Interval)
+}
+
+func maxEnd(n *avltree.Node) interface{} {
+	return n.Value.(*entry).max
+}
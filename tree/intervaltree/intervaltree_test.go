@@ -0,0 +1,294 @@
+package intervaltree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/morganxf/algorithm/tree/avltree"
+	"github.com/morganxf/algorithm/util"
+)
+
+func collect(it *OverlapIterator) []Interval {
+	intervals := []Interval{}
+	for it.Next() {
+		intervals = append(intervals, it.Interval())
+	}
+	return intervals
+}
+
+func TestIntervalTreeInsertGetDelete(t *testing.T) {
+	tree := NewWithIntComparator()
+	tree.Insert(5, 10, "a")
+	tree.Insert(1, 3, "b")
+	tree.Insert(5, 8, "c")
+	tree.Insert(12, 20, "d")
+	tree.Insert(5, 10, "e") // overwrite
+
+	if actualValue := tree.Size(); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if actualValue := fmt.Sprint(tree.Intervals()); actualValue != "[[1, 3] [5, 8] [5, 10] [12, 20]]" {
+		t.Errorf("Got %v expected %v", actualValue, "[[1, 3] [5, 8] [5, 10] [12, 20]]")
+	}
+	if actualValue := fmt.Sprint(tree.Values()); actualValue != "[b c e d]" {
+		t.Errorf("Got %v expected %v", actualValue, "[b c e d]")
+	}
+	if actualValue, found := tree.MaxEnd(); actualValue != 20 || !found {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, found, 20, true)
+	}
+
+	tests := [][]interface{}{
+		{5, 10, "e", true},
+		{5, 8, "c", true},
+		{5, 9, nil, false},
+		{12, 20, "d", true},
+		{0, 0, nil, false},
+	}
+	for _, test := range tests {
+		actualValue, actualFound := tree.Get(test[0], test[1])
+		if actualValue != test[2] || actualFound != test[3] {
+			t.Errorf("Got %v expected %v", actualValue, test[2])
+		}
+	}
+
+	tree.Delete(12, 20)
+	tree.Delete(5, 9) // not in tree
+	if actualValue, found := tree.MaxEnd(); actualValue != 10 || !found {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, found, 10, true)
+	}
+	tree.Delete(5, 10)
+	tree.Delete(1, 3)
+	tree.Delete(5, 8)
+	if actualValue := tree.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	tree.Insert(1, 1, nil)
+	tree.Clear()
+	if actualValue := tree.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestIntervalTreeInsertInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got %v expected %v", r, "panic")
+		}
+	}()
+	NewWithIntComparator().Insert(2, 1, nil)
+}
+
+func TestIntervalTreeOverlaps(t *testing.T) {
+	tree := NewWithIntComparator()
+	tree.Insert(15, 20, nil)
+	tree.Insert(10, 30, nil)
+	tree.Insert(17, 19, nil)
+	tree.Insert(5, 20, nil)
+	tree.Insert(12, 15, nil)
+	tree.Insert(30, 40, nil)
+
+	tests := [][]interface{}{
+		{16, 16, "[[5, 20] [10, 30] [15, 20]]"},
+		{30, 30, "[[10, 30] [30, 40]]"},
+		{0, 4, "[]"},
+		{41, 50, "[]"},
+		{18, 19, "[[5, 20] [10, 30] [15, 20] [17, 19]]"},
+		{21, 29, "[[10, 30]]"},
+		{0, 100, "[[5, 20] [10, 30] [12, 15] [15, 20] [17, 19] [30, 40]]"},
+	}
+	for _, test := range tests {
+		if actualValue := fmt.Sprint(collect(tree.OverlapsRange(test[0], test[1]))); actualValue != test[2] {
+			t.Errorf("Got %v expected %v", actualValue, test[2])
+		}
+	}
+	if actualValue := fmt.Sprint(collect(tree.Overlaps(13))); actualValue != "[[5, 20] [10, 30] [12, 15]]" {
+		t.Errorf("Got %v expected %v", actualValue, "[[5, 20] [10, 30] [12, 15]]")
+	}
+
+	it := tree.Overlaps(40)
+	if actualValue := it.First(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := it.Key(); actualValue != (Interval{30, 40}) {
+		t.Errorf("Got %v expected %v", actualValue, Interval{30, 40})
+	}
+	if actualValue := it.Next(); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := it.Key(); actualValue != nil {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+}
+
+func TestIntervalTreeIterator(t *testing.T) {
+	tree := NewWithStringComparator()
+	tree.Insert("b", "d", 1)
+	tree.Insert("a", "z", 2)
+	tree.Insert("c", "c", 3)
+
+	it := tree.Iterator()
+	expected := []Interval{{"a", "z"}, {"b", "d"}, {"c", "c"}}
+	for i := 0; it.Next(); i++ {
+		if actualValue := it.Key(); actualValue != expected[i] {
+			t.Errorf("Got %v expected %v", actualValue, expected[i])
+		}
+	}
+	for i := len(expected) - 1; it.Prev(); i-- {
+		if actualValue := it.Interval(); actualValue != expected[i] {
+			t.Errorf("Got %v expected %v", actualValue, expected[i])
+		}
+	}
+	if actualValue := it.Last(); actualValue != true || it.Value() != 3 {
+		t.Errorf("Got %v expected %v", it.Value(), 3)
+	}
+}
+
+func TestIntervalTreeRandom(t *testing.T) {
+	tree := NewWithIntComparator()
+	r := rand.New(rand.NewSource(7))
+	expected := map[Interval]bool{}
+	for i := 0; i < 3000; i++ {
+		start := r.Intn(1000)
+		interval := Interval{start, start + r.Intn(50)}
+		if r.Intn(3) == 0 {
+			tree.Delete(interval.Start, interval.End)
+			delete(expected, interval)
+		} else {
+			tree.Insert(interval.Start, interval.End, i)
+			expected[interval] = true
+		}
+		if i%300 == 0 {
+			assertValidIntervalTree(t, tree)
+		}
+	}
+	assertValidIntervalTree(t, tree)
+	if actualValue := tree.Size(); actualValue != len(expected) {
+		t.Errorf("Got %v expected %v", actualValue, len(expected))
+	}
+
+	for i := 0; i < 200; i++ {
+		from := r.Intn(1100) - 50
+		to := from + r.Intn(30)
+		count := 0
+		for interval := range expected {
+			if interval.Start.(int) <= to && interval.End.(int) >= from {
+				count++
+			}
+		}
+		actual := collect(tree.OverlapsRange(from, to))
+		if len(actual) != count {
+			t.Errorf("Got %v expected %v", len(actual), count)
+		}
+		for j, interval := range actual {
+			if !expected[interval] || interval.Start.(int) > to || interval.End.(int) < from {
+				t.Errorf("Got %v not overlapping [%v, %v]", interval, from, to)
+			}
+			if j > 0 && tree.compare(actual[j-1], interval) >= 0 {
+				t.Errorf("Got %v before %v", actual[j-1], interval)
+			}
+		}
+	}
+}
+
+func TestIntervalTreeZeroValue(t *testing.T) {
+	tree := &Tree{Comparator: util.IntComparator}
+	if actualValue, found := tree.MaxEnd(); actualValue != nil || found {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, found, nil, false)
+	}
+	tree.Insert(1, 4, "a")
+	tree.Insert(2, 3, "b")
+	if actualValue, expectedValue := fmt.Sprint(collect(tree.Overlaps(4))), "[[1, 4]]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.String(), "IntervalTree\n│   ┌── [2, 3]\n└── [1, 4]\n"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+// assertValidIntervalTree checks the largest End of every subtree and the order of the intervals,
+// the balancing is checked by the avltree tests.
+func assertValidIntervalTree(t *testing.T, tree *Tree) {
+	var check func(n *avltree.Node) int
+	check = func(n *avltree.Node) int {
+		if n == nil {
+			return 0
+		}
+		max := interval(n).End
+		for d, child := range n.Children {
+			if child == nil {
+				continue
+			}
+			if cmp := tree.compare(interval(child), interval(n)); (d == 0 && cmp >= 0) || (d == 1 && cmp <= 0) {
+				t.Errorf("Got child %v on the wrong side of %v", child, n)
+			}
+			if tree.Comparator(maxEnd(child), max) > 0 {
+				max = maxEnd(child)
+			}
+		}
+		if maxEnd(n) != max {
+			t.Errorf("Got max %v expected %v for %v", maxEnd(n), max, n)
+		}
+		return check(n.Children[0]) + check(n.Children[1]) + 1
+	}
+	if size := check(tree.avl().Root); size != tree.Size() {
+		t.Errorf("Got size %v expected %v", size, tree.Size())
+	}
+}
+
+func benchmarkInsert(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Insert(n, n+10, struct{}{})
+		}
+	}
+}
+
+func benchmarkOverlaps(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			it := tree.Overlaps(n)
+			for it.Next() {
+			}
+		}
+	}
+}
+
+func BenchmarkIntervalTreeInsert1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkInsert(b, tree, size)
+}
+
+func BenchmarkIntervalTreeInsert10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	tree := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkInsert(b, tree, size)
+}
+
+func BenchmarkIntervalTreeOverlaps1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		tree.Insert(n, n+10, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkOverlaps(b, tree, size)
+}
+
+func BenchmarkIntervalTreeOverlaps10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	tree := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		tree.Insert(n, n+10, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkOverlaps(b, tree, size)
+}
@@ -0,0 +1,202 @@
+package intervaltree
+
+import (
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/tree/avltree"
+)
+
+func assertIteratorImplementation() {
+	var _ container.ReverseIteratorWithKey = (*Iterator)(nil)
+	var _ container.IteratorWithKey = (*OverlapIterator)(nil)
+}
+
+// Iterator walks all the intervals ordered by Start then End, Key returns the current Interval.
+type Iterator struct {
+	tree     *Tree
+	node     *avltree.Node
+	position position
+}
+
+type position byte
+
+const (
+	begin, between, end = 0, 1, 2
+)
+
+func (t *Tree) Iterator() *Iterator {
+	return &Iterator{tree: t, node: nil, position: begin}
+}
+
+func (it *Iterator) Next() bool {
+	switch it.position {
+	case begin:
+		it.node = it.tree.avl().Left()
+		it.position = between
+	case between:
+		it.node = it.node.Next()
+	}
+	if it.node == nil {
+		it.position = end
+		return false
+	}
+	return true
+}
+
+func (it *Iterator) Prev() bool {
+	switch it.position {
+	case end:
+		it.node = it.tree.avl().Right()
+		it.position = between
+	case between:
+		it.node = it.node.Prev()
+	}
+	if it.node == nil {
+		it.position = begin
+		return false
+	}
+	return true
+}
+
+func (it *Iterator) Key() interface{} {
+	if it.node == nil {
+		return nil
+	}
+	return interval(it.node)
+}
+
+// Interval returns the current interval, the zero Interval if the iterator is not on an element.
+func (it *Iterator) Interval() Interval {
+	if it.node == nil {
+		return Interval{}
+	}
+	return interval(it.node)
+}
+
+func (it *Iterator) Value() interface{} {
+	if it.node == nil {
+		return nil
+	}
+	return it.node.Value.(*entry).value
+}
+
+func (it *Iterator) Begin() {
+	it.node = nil
+	it.position = begin
+}
+
+func (it *Iterator) End() {
+	it.node = nil
+	it.position = end
+}
+
+func (it *Iterator) First() bool {
+	it.Begin()
+	return it.Next()
+}
+
+func (it *Iterator) Last() bool {
+	it.End()
+	return it.Prev()
+}
+
+// OverlapIterator walks the intervals overlapping [from, to] ordered by Start then End.
+type OverlapIterator struct {
+	tree     *Tree
+	from     interface{}
+	to       interface{}
+	node     *avltree.Node
+	position position
+}
+
+func (it *OverlapIterator) Next() bool {
+	switch it.position {
+	case begin:
+		it.node = it.first(it.tree.avl().Root)
+		it.position = between
+	case between:
+		it.node = it.next(it.node)
+	}
+	if it.node == nil {
+		it.position = end
+		return false
+	}
+	return true
+}
+
+func (it *OverlapIterator) Key() interface{} {
+	if it.node == nil {
+		return nil
+	}
+	return interval(it.node)
+}
+
+// Interval returns the current interval, the zero Interval if the iterator is not on an element.
+func (it *OverlapIterator) Interval() Interval {
+	if it.node == nil {
+		return Interval{}
+	}
+	return interval(it.node)
+}
+
+func (it *OverlapIterator) Value() interface{} {
+	if it.node == nil {
+		return nil
+	}
+	return it.node.Value.(*entry).value
+}
+
+func (it *OverlapIterator) Begin() {
+	it.node = nil
+	it.position = begin
+}
+
+func (it *OverlapIterator) First() bool {
+	it.Begin()
+	return it.Next()
+}
+
+// first返回以n为根的子树中第一个与[from, to]相交的node
+// 子树的max小于from时整棵子树都不相交; node的Start大于to时其右子树也不相交
+func (it *OverlapIterator) first(n *avltree.Node) *avltree.Node {
+	t := it.tree
+	for n != nil {
+		if t.Comparator(maxEnd(n), it.from) < 0 {
+			return nil
+		}
+		if left := it.first(n.Children[0]); left != nil {
+			return left
+		}
+		if t.Comparator(interval(n).Start, it.to) > 0 {
+			return nil
+		}
+		if t.Comparator(interval(n).End, it.from) >= 0 {
+			return n
+		}
+		n = n.Children[1]
+	}
+	return nil
+}
+
+// next返回n之后第一个与[from, to]相交的node: 先找右子树, 再沿着祖先向上找
+// 从左孩子回到祖先时, 先检查祖先本身再找祖先的右子树
+func (it *OverlapIterator) next(n *avltree.Node) *avltree.Node {
+	t := it.tree
+	if found := it.first(n.Children[1]); found != nil {
+		return found
+	}
+	for cur, parent := n, n.Parent; parent != nil; cur, parent = parent, parent.Parent {
+		if parent.Children[1] == cur {
+			continue
+		}
+		if t.Comparator(interval(parent).Start, it.to) > 0 {
+			return nil
+		}
+		if t.overlaps(parent, it.from, it.to) {
+			return parent
+		}
+		if found := it.first(parent.Children[1]); found != nil {
+			return found
+		}
+	}
+	return nil
+}
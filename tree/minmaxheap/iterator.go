@@ -0,0 +1,60 @@
+package minmaxheap
+
+import "github.com/morganxf/algorithm/container"
+
+func assertIteratorImplementation() {
+	var _ container.ReverseIteratorWithIndex = (*Iterator)(nil)
+}
+
+// Iterator walks the values in storage order, use PopMin or PopMax for comparator order.
+type Iterator struct {
+	heap  *Heap
+	index int
+}
+
+func (heap *Heap) Iterator() Iterator {
+	return Iterator{heap: heap, index: -1}
+}
+
+func (it *Iterator) Next() bool {
+	if it.index < it.heap.Size() {
+		it.index++
+	}
+	return it.heap.withinRange(it.index)
+}
+
+func (it *Iterator) Prev() bool {
+	if it.index >= 0 {
+		it.index--
+	}
+	return it.heap.withinRange(it.index)
+}
+
+func (it *Iterator) Value() interface{} {
+	if !it.heap.withinRange(it.index) {
+		return nil
+	}
+	return it.heap.values[it.index]
+}
+
+func (it *Iterator) Index() int {
+	return it.index
+}
+
+func (it *Iterator) Begin() {
+	it.index = -1
+}
+
+func (it *Iterator) End() {
+	it.index = it.heap.Size()
+}
+
+func (it *Iterator) First() bool {
+	it.Begin()
+	return it.Next()
+}
+
+func (it *Iterator) Last() bool {
+	it.End()
+	return it.Prev()
+}
@@ -0,0 +1,211 @@
+package minmaxheap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+// Heap is a min-max heap, a double-ended priority queue: both the smallest and the largest value
+// can be peeked in O(1) and popped in O(log n).
+type Heap struct {
+	values       []interface{}
+	Comparator   util.Comparator
+	ValueDecoder util.ValueDecoder // 可选, FromJSON用于还原值, 默认整数还原为int, 其他数字为float64
+}
+
+func NewWith(comparator util.Comparator) *Heap {
+	return &Heap{Comparator: comparator}
+}
+
+func NewWithIntComparator() *Heap {
+	return &Heap{Comparator: util.IntComparator}
+}
+
+func NewWithStringComparator() *Heap {
+	return &Heap{Comparator: util.StringComparator}
+}
+
+// Push adds values to the heap, several values at once are heapified in O(n).
+func (heap *Heap) Push(values ...interface{}) {
+	if len(values) == 1 {
+		heap.values = append(heap.values, values[0])
+		heap.bubbleUp(len(heap.values) - 1)
+	} else {
+		heap.values = append(heap.values, values...)
+		// 从最后一个孩子的父亲开始, 遍历到根节点, 调整树结构
+		for i := len(heap.values)/2 - 1; i >= 0; i-- {
+			heap.trickleDown(i)
+		}
+	}
+}
+
+// PopMin removes and returns the smallest value.
+func (heap *Heap) PopMin() (interface{}, bool) {
+	if heap.Empty() {
+		return nil, false
+	}
+	return heap.removeAt(0), true
+}
+
+// PopMax removes and returns the largest value.
+func (heap *Heap) PopMax() (interface{}, bool) {
+	if heap.Empty() {
+		return nil, false
+	}
+	return heap.removeAt(heap.maxIndex()), true
+}
+
+// PeekMin returns the smallest value without removing it.
+func (heap *Heap) PeekMin() (interface{}, bool) {
+	if heap.Empty() {
+		return nil, false
+	}
+	return heap.values[0], true
+}
+
+// PeekMax returns the largest value without removing it.
+func (heap *Heap) PeekMax() (interface{}, bool) {
+	if heap.Empty() {
+		return nil, false
+	}
+	return heap.values[heap.maxIndex()], true
+}
+
+func (heap *Heap) Empty() bool {
+	return len(heap.values) == 0
+}
+
+func (heap *Heap) Size() int {
+	return len(heap.values)
+}
+
+func (heap *Heap) Clear() {
+	heap.values = nil
+}
+
+// Values returns the values in storage order.
+func (heap *Heap) Values() []interface{} {
+	values := make([]interface{}, len(heap.values))
+	copy(values, heap.values)
+	return values
+}
+
+func (heap *Heap) String() string {
+	str := "MinMaxHeap\n"
+	values := []string{}
+	for _, value := range heap.values {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// 以0为起始点, 偶数层为min层, 奇数层为max层
+// min层节点不大于子树中所有节点, max层节点不小于子树中所有节点
+func isMinLevel(index int) bool {
+	level := 0
+	for index++; index > 1; index >>= 1 {
+		level++
+	}
+	return level%2 == 0
+}
+
+// less在min层按照Comparator比较, 在max层反过来比较
+func (heap *Heap) less(i int, j int, min bool) bool {
+	cmp := heap.Comparator(heap.values[i], heap.values[j])
+	if min {
+		return cmp < 0
+	}
+	return cmp > 0
+}
+
+func (heap *Heap) swap(i int, j int) {
+	heap.values[i], heap.values[j] = heap.values[j], heap.values[i]
+}
+
+// maxIndex返回最大值的位置, 为根的两个孩子中较大的一个
+func (heap *Heap) maxIndex() int {
+	switch {
+	case len(heap.values) == 1:
+		return 0
+	case len(heap.values) == 2 || heap.Comparator(heap.values[1], heap.values[2]) >= 0:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// removeAt用最后一个元素替换index处的元素并向下调整
+func (heap *Heap) removeAt(index int) interface{} {
+	value := heap.values[index]
+	last := len(heap.values) - 1
+	heap.values[index] = heap.values[last]
+	heap.values[last] = nil
+	heap.values = heap.values[:last]
+	if index < last {
+		heap.trickleDown(index)
+	}
+	return value
+}
+
+func (heap *Heap) bubbleUp(index int) {
+	if index == 0 {
+		return
+	}
+	min := isMinLevel(index)
+	parentIndex := (index - 1) >> 1
+	if heap.less(parentIndex, index, min) {
+		// 与父亲不满足当前层的顺序, 交换后沿着父亲所在的层向上调整
+		heap.swap(index, parentIndex)
+		heap.bubbleUpGrandparent(parentIndex, !min)
+	} else {
+		heap.bubbleUpGrandparent(index, min)
+	}
+}
+
+// bubbleUpGrandparent沿着同类的层(祖父)向上调整
+func (heap *Heap) bubbleUpGrandparent(index int, min bool) {
+	for index > 2 {
+		grandparentIndex := ((index-1)>>1 - 1) >> 1
+		if !heap.less(index, grandparentIndex, min) {
+			break
+		}
+		heap.swap(index, grandparentIndex)
+		index = grandparentIndex
+	}
+}
+
+func (heap *Heap) trickleDown(index int) {
+	min := isMinLevel(index)
+	size := len(heap.values)
+	for {
+		// 在孩子和孙子中找到最小(min层)或最大(max层)的节点
+		smallest := -1
+		leftIndex := index<<1 + 1
+		for _, i := range [...]int{leftIndex, leftIndex + 1, leftIndex<<1 + 1, leftIndex<<1 + 2, leftIndex<<1 + 3, leftIndex<<1 + 4} {
+			if i < size && (smallest < 0 || heap.less(i, smallest, min)) {
+				smallest = i
+			}
+		}
+		if smallest < 0 || !heap.less(smallest, index, min) {
+			return
+		}
+		heap.swap(smallest, index)
+		if smallest <= leftIndex+1 {
+			// 孩子没有孙子, 调整结束
+			return
+		}
+		// 孙子换下来的值可能违反其父亲(另一类层)的顺序
+		parentIndex := (smallest - 1) >> 1
+		if heap.less(parentIndex, smallest, min) {
+			heap.swap(parentIndex, smallest)
+		}
+		index = smallest
+	}
+}
+
+func (heap *Heap) withinRange(index int) bool {
+	return index >= 0 && index < len(heap.values)
+}
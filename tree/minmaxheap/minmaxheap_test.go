@@ -0,0 +1,296 @@
+package minmaxheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func TestMinMaxHeapPushAndPop(t *testing.T) {
+	heap := NewWithIntComparator()
+
+	if actualValue, ok := heap.PopMin(); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue, ok := heap.PeekMax(); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	heap.Push(5)
+	if actualValue, ok := heap.PeekMax(); actualValue != 5 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	heap.Push(3)
+	heap.Push(8)
+	heap.Push(1)
+	heap.Push(9)
+	heap.Push(7)
+
+	if actualValue := heap.Size(); actualValue != 6 {
+		t.Errorf("Got %v expected %v", actualValue, 6)
+	}
+	if actualValue, ok := heap.PeekMin(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, ok := heap.PeekMax(); actualValue != 9 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 9)
+	}
+
+	tests := [][]interface{}{
+		{true, 1},
+		{false, 9},
+		{false, 8},
+		{true, 3},
+		{true, 5},
+		{false, 7},
+	}
+	for _, test := range tests {
+		var actualValue interface{}
+		if test[0].(bool) {
+			actualValue, _ = heap.PopMin()
+		} else {
+			actualValue, _ = heap.PopMax()
+		}
+		if actualValue != test[1] {
+			t.Errorf("Got %v expected %v", actualValue, test[1])
+		}
+	}
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestMinMaxHeapPushBulk(t *testing.T) {
+	heap := NewWithIntComparator()
+	heap.Push(15, 20, 3, 1, 2, 9, 30, 4)
+
+	expected := []int{1, 2, 3, 4, 9, 15, 20, 30}
+	for _, expectedValue := range expected[:4] {
+		if actualValue, _ := heap.PopMin(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	for i := len(expected) - 1; i >= 4; i-- {
+		if actualValue, _ := heap.PopMax(); actualValue != expected[i] {
+			t.Errorf("Got %v expected %v", actualValue, expected[i])
+		}
+	}
+	heap.Push(2, 1)
+	heap.Clear()
+	if actualValue := heap.Size(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestMinMaxHeapIterator(t *testing.T) {
+	heap := NewWithIntComparator()
+	heap.Push(3, 1, 2)
+	values := heap.Values()
+
+	it := heap.Iterator()
+	count := 0
+	for it.Next() {
+		if actualValue := it.Value(); actualValue != values[it.Index()] {
+			t.Errorf("Got %v expected %v", actualValue, values[it.Index()])
+		}
+		count++
+	}
+	if actualValue := count; actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	for it.Prev() {
+		count--
+	}
+	if actualValue := count; actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := it.Last(); actualValue != true || it.Index() != 2 {
+		t.Errorf("Got %v expected %v", it.Index(), 2)
+	}
+	if actualValue := it.First(); actualValue != true || it.Value() != 1 {
+		t.Errorf("Got %v expected %v", it.Value(), 1)
+	}
+}
+
+func TestMinMaxHeapSerialization(t *testing.T) {
+	heap := NewWithStringComparator()
+	heap.Push("c", "a", "d", "b")
+
+	var err error
+	assert := func() {
+		if actualValue := heap.Size(); actualValue != 4 {
+			t.Errorf("Got %v expected %v", actualValue, 4)
+		}
+		if actualValue, ok := heap.PeekMin(); actualValue != "a" || !ok {
+			t.Errorf("Got %v expected %v", actualValue, "a")
+		}
+		if actualValue, ok := heap.PeekMax(); actualValue != "d" || !ok {
+			t.Errorf("Got %v expected %v", actualValue, "d")
+		}
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	assert()
+
+	json, err := heap.ToJSON()
+	assert()
+
+	err = heap.FromJSON(json)
+	assert()
+
+	json, err = heap.MarshalJSON()
+	assert()
+
+	err = heap.UnmarshalJSON([]byte(`["x","y"]`))
+	if actualValue, _ := heap.PeekMax(); actualValue != "y" || err != nil {
+		t.Errorf("Got %v expected %v", actualValue, "y")
+	}
+	// 字符串的comparator无法比较数字, 返回错误且堆不变
+	if err = heap.FromJSON([]byte(`[1,2]`)); err == nil {
+		t.Errorf("Expected an error for values the comparator can't compare")
+	}
+	if actualValue := heap.Size(); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+
+	ints := NewWithIntComparator()
+	ints.Push(3, 1, 4, 2)
+	json, err = ints.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	restored := NewWithIntComparator()
+	if err = restored.FromJSON(json); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualMin, _ := restored.PopMin(); actualMin != 1 {
+		t.Errorf("Got %v expected %v", actualMin, 1)
+	}
+	if actualMax, _ := restored.PopMax(); actualMax != 4 {
+		t.Errorf("Got %v expected %v", actualMax, 4)
+	}
+	if err = new(Heap).FromJSON(json); err != util.ErrNotConstructed {
+		t.Errorf("Got %v expected %v", err, util.ErrNotConstructed)
+	}
+}
+
+func TestMinMaxHeapRandom(t *testing.T) {
+	heap := NewWithIntComparator()
+	r := rand.New(rand.NewSource(11))
+	expected := []int{}
+	for i := 0; i < 3000; i++ {
+		switch r.Intn(4) {
+		case 0:
+			sort.Ints(expected)
+			if len(expected) > 0 {
+				expected = expected[1:]
+			}
+			heap.PopMin()
+		case 1:
+			sort.Ints(expected)
+			if len(expected) > 0 {
+				expected = expected[:len(expected)-1]
+			}
+			heap.PopMax()
+		case 2:
+			values := []interface{}{}
+			for j := r.Intn(5); j > 0; j-- {
+				value := r.Intn(1000)
+				expected = append(expected, value)
+				values = append(values, value)
+			}
+			heap.Push(values...)
+		default:
+			value := r.Intn(1000)
+			expected = append(expected, value)
+			heap.Push(value)
+		}
+		if i%100 == 0 {
+			assertValidMinMaxHeap(t, heap)
+		}
+	}
+	assertValidMinMaxHeap(t, heap)
+	sort.Ints(expected)
+	if actualValue := heap.Size(); actualValue != len(expected) {
+		t.Errorf("Got %v expected %v", actualValue, len(expected))
+	}
+	for _, expectedValue := range expected {
+		if actualValue, _ := heap.PopMin(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			break
+		}
+	}
+}
+
+func assertValidMinMaxHeap(t *testing.T, heap *Heap) {
+	for i := 1; i < heap.Size(); i++ {
+		// 每个节点都要满足所有祖先所在层的顺序
+		for ancestor := (i - 1) >> 1; ancestor >= 0; ancestor = (ancestor - 1) >> 1 {
+			if heap.less(i, ancestor, isMinLevel(ancestor)) {
+				t.Errorf("Got %v at %v out of order with ancestor %v at %v", heap.values[i], i, heap.values[ancestor], ancestor)
+			}
+			if ancestor == 0 {
+				break
+			}
+		}
+	}
+}
+
+func benchmarkPush(b *testing.B, heap *Heap, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			heap.Push(n)
+		}
+	}
+}
+
+func benchmarkPopMinAndMax(b *testing.B, heap *Heap, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n += 2 {
+			heap.PopMin()
+			heap.PopMax()
+		}
+	}
+}
+
+func BenchmarkMinMaxHeapPush1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	heap := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkMinMaxHeapPush100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	heap := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkMinMaxHeapPop1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	heap := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPopMinAndMax(b, heap, size)
+}
+
+func BenchmarkMinMaxHeapPop100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	heap := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPopMinAndMax(b, heap, size)
+}
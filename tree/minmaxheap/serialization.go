@@ -0,0 +1,56 @@
+package minmaxheap
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/util"
+)
+
+func assertSerializationImplementation() {
+	var _ container.JSONSerializer = (*Heap)(nil)
+	var _ container.JSONDeserializer = (*Heap)(nil)
+	var _ json.Marshaler = (*Heap)(nil)
+	var _ json.Unmarshaler = (*Heap)(nil)
+}
+
+// ToJSON encodes the values of the heap as a JSON array in storage order.
+func (heap *Heap) ToJSON() ([]byte, error) {
+	return json.Marshal(heap.Values())
+}
+
+// FromJSON replaces the values of the heap with the elements of a JSON array.
+// The array does not need to be in heap order, the heap is rebuilt from it.
+// Values are restored by heap.ValueDecoder, or as util.UnmarshalValues does if it is nil.
+// A Heap without a Comparator returns util.ErrNotConstructed.
+// The heap is unchanged if the comparator can't compare the decoded values.
+func (heap *Heap) FromJSON(data []byte) (err error) {
+	if heap.Comparator == nil {
+		return util.ErrNotConstructed
+	}
+	values, err := util.UnmarshalValues(data, heap.ValueDecoder)
+	if err != nil {
+		return err
+	}
+	// 在新的Heap上重建, comparator无法比较解码出的值时(通常是类型断言panic)保持原堆不变
+	rebuilt := &Heap{Comparator: heap.Comparator}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("decoded values can't be compared: %v", r)
+		}
+	}()
+	rebuilt.Push(values...)
+	heap.values = rebuilt.values
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, it is equivalent to ToJSON.
+func (heap *Heap) MarshalJSON() ([]byte, error) {
+	return heap.ToJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it is equivalent to FromJSON.
+func (heap *Heap) UnmarshalJSON(data []byte) error {
+	return heap.FromJSON(data)
+}
@@ -0,0 +1,79 @@
+package pagedbtree
+
+import "container/list"
+
+// cache is a write-back LRU cache of raw pages.
+type cache struct {
+	pager    Pager
+	capacity int
+	pages    map[PageID]*list.Element
+	lru      *list.List // 最近使用的page在前
+}
+
+type cachedPage struct {
+	id    PageID
+	data  []byte
+	dirty bool
+}
+
+func newCache(pager Pager, capacity int) *cache {
+	return &cache{pager: pager, capacity: capacity, pages: make(map[PageID]*list.Element), lru: list.New()}
+}
+
+// get returns the content of page id. The returned slice must not be modified.
+func (c *cache) get(id PageID) ([]byte, error) {
+	if element, ok := c.pages[id]; ok {
+		c.lru.MoveToFront(element)
+		return element.Value.(*cachedPage).data, nil
+	}
+	data := make([]byte, c.pager.PageSize())
+	if err := c.pager.ReadPage(id, data); err != nil {
+		return nil, err
+	}
+	c.add(&cachedPage{id: id, data: data})
+	return data, nil
+}
+
+// put replaces the content of page id, it is written to the pager on eviction or flush.
+func (c *cache) put(id PageID, data []byte) {
+	if element, ok := c.pages[id]; ok {
+		page := element.Value.(*cachedPage)
+		page.data = data
+		page.dirty = true
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.add(&cachedPage{id: id, data: data, dirty: true})
+}
+
+// add淘汰最久未使用的page, 脏page需要先写回. 写回失败时保留该page, 错误由下一次flush返回,
+// 所以put不会失败, 一次修改操作不会只有部分page进入cache
+func (c *cache) add(page *cachedPage) {
+	c.pages[page.id] = c.lru.PushFront(page)
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		evicted := oldest.Value.(*cachedPage)
+		if evicted.dirty {
+			if err := c.pager.WritePage(evicted.id, evicted.data); err != nil {
+				return
+			}
+		}
+		c.lru.Remove(oldest)
+		delete(c.pages, evicted.id)
+	}
+}
+
+// flush writes all dirty pages to the pager.
+func (c *cache) flush() error {
+	for element := c.lru.Back(); element != nil; element = element.Prev() {
+		page := element.Value.(*cachedPage)
+		if !page.dirty {
+			continue
+		}
+		if err := c.pager.WritePage(page.id, page.data); err != nil {
+			return err
+		}
+		page.dirty = false
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package pagedbtree
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Codec converts keys or values to and from their on-page representation.
+type Codec interface {
+	Encode(value interface{}) ([]byte, error)
+	Decode(data []byte) (interface{}, error)
+}
+
+// IntCodec encodes int as a signed varint.
+type IntCodec struct{}
+
+// StringCodec encodes string as its bytes.
+type StringCodec struct{}
+
+var errInvalidVarint = errors.New("invalid varint")
+
+func (IntCodec) Encode(value interface{}) ([]byte, error) {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutVarint(buf, int64(value.(int)))
+	return buf[:n], nil
+}
+
+func (IntCodec) Decode(data []byte) (interface{}, error) {
+	i, n := binary.Varint(data)
+	if n <= 0 {
+		return nil, errInvalidVarint
+	}
+	return int(i), nil
+}
+
+func (StringCodec) Encode(value interface{}) ([]byte, error) {
+	return []byte(value.(string)), nil
+}
+
+func (StringCodec) Decode(data []byte) (interface{}, error) {
+	return string(data), nil
+}
@@ -0,0 +1,113 @@
+package pagedbtree
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// 空闲page列表page布局: [type 1byte][PageID数目 2bytes][下一个列表page 4bytes][PageID 4bytes...]
+const freeListHeaderSize = 7
+
+// allocate分配一个page, 优先复用空闲page. 新分配的page在下次Sync之前可以原地修改
+func (t *Tree) allocate() PageID {
+	var id PageID
+	if len(t.freePages) > 0 {
+		id = t.freePages[len(t.freePages)-1]
+		t.freePages = t.freePages[:len(t.freePages)-1]
+	} else {
+		id = t.meta.pageCount
+		t.meta.pageCount++
+	}
+	t.fresh[id] = true
+	t.allocated = append(t.allocated, id)
+	return id
+}
+
+// release把不再使用的page加入空闲列表. 上次Sync之后分配的page可以立即复用,
+// 其余的page仍被上次Sync的meta引用, 下次Sync之后才能复用
+func (t *Tree) release(id PageID) {
+	if t.fresh[id] {
+		delete(t.fresh, id)
+		t.freePages = append(t.freePages, id)
+	} else {
+		t.pendingPages = append(t.pendingPages, id)
+	}
+}
+
+// rollback撤销修改操作中的page分配, 按照相反的顺序放回freePages, 恢复原来的顺序
+func (t *Tree) rollback(saved meta) {
+	for i := len(t.allocated) - 1; i >= 0; i-- {
+		id := t.allocated[i]
+		delete(t.fresh, id)
+		if id < saved.pageCount {
+			t.freePages = append(t.freePages, id)
+		}
+	}
+	t.meta = saved
+}
+
+// relocate把以id为根的子树中修改过的node复制到新的page, 返回根的新PageID.
+// 只有本次修改操作加载过的node可能被修改, 孩子的PageID变化时父亲也被修改
+func (t *Tree) relocate(id PageID) PageID {
+	n, ok := t.nodes[id]
+	if !ok {
+		return id
+	}
+	for i, child := range n.children {
+		if moved := t.relocate(child); moved != child {
+			n.children[i] = moved
+			n.dirty = true
+		}
+	}
+	if n.dirty && !t.fresh[n.id] {
+		t.freed = append(t.freed, n.id)
+		n.id = t.allocate()
+	}
+	return n.id
+}
+
+// encodeFreeList把free编码到从first开始的连续page中
+func (t *Tree) encodeFreeList(free []PageID, first PageID) ([]PageID, [][]byte) {
+	perPage := (t.pager.PageSize() - freeListHeaderSize) / 4
+	var ids []PageID
+	var pages [][]byte
+	for i := 0; i < len(free); i += perPage {
+		chunk := free[i:]
+		if len(chunk) > perPage {
+			chunk = chunk[:perPage]
+		}
+		id := first + PageID(len(ids))
+		data := make([]byte, t.pager.PageSize())
+		data[0] = pageTypeFreeList
+		binary.LittleEndian.PutUint16(data[1:], uint16(len(chunk)))
+		if i+perPage < len(free) {
+			binary.LittleEndian.PutUint32(data[3:], uint32(id+1))
+		}
+		for j, free := range chunk {
+			binary.LittleEndian.PutUint32(data[freeListHeaderSize+4*j:], uint32(free))
+		}
+		ids = append(ids, id)
+		pages = append(pages, data)
+	}
+	return ids, pages
+}
+
+func (t *Tree) readFreeList() error {
+	t.freePages, t.pendingPages, t.freeListPages = nil, nil, nil
+	for id := t.meta.freeList; id != 0; {
+		data, err := t.cache.get(id)
+		if err != nil {
+			return err
+		}
+		count := int(binary.LittleEndian.Uint16(data[1:]))
+		if data[0] != pageTypeFreeList || freeListHeaderSize+4*count > len(data) {
+			return fmt.Errorf("page %d: %w", id, errCorruptPage)
+		}
+		for j := 0; j < count; j++ {
+			t.freePages = append(t.freePages, PageID(binary.LittleEndian.Uint32(data[freeListHeaderSize+4*j:])))
+		}
+		t.freeListPages = append(t.freeListPages, id)
+		id = PageID(binary.LittleEndian.Uint32(data[3:]))
+	}
+	return nil
+}
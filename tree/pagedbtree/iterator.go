@@ -0,0 +1,169 @@
+package pagedbtree
+
+import "github.com/morganxf/algorithm/container"
+
+func assertIteratorImplementation() {
+	var _ container.ReverseIteratorWithKey = (*Iterator)(nil)
+}
+
+// Iterator holds the path from the root to the current entry, since nodes have no parent.
+// Modifying the tree invalidates its iterators. Next and Prev return false on I/O errors, check Err.
+type Iterator struct {
+	tree     *Tree
+	path     []frame
+	position position
+	err      error
+}
+
+// frame为路径上的一个node. 路径最后的frame中index为当前entry的index，其余frame中index为向下经过的孩子的index
+type frame struct {
+	node  *node
+	index int
+}
+
+type position byte
+
+const (
+	begin, between, end position = 0, 1, 2
+)
+
+func (t *Tree) Iterator() *Iterator {
+	return &Iterator{tree: t, position: begin}
+}
+
+func (it *Iterator) Next() bool {
+	switch it.position {
+	case end:
+		return false
+	case begin:
+		it.path = it.path[:0]
+		it.descend(it.tree.meta.root, 0)
+	case between:
+		top := &it.path[len(it.path)-1]
+		if !top.node.isLeaf() {
+			// 进入entry右侧的孩子，再一直向左
+			top.index++
+			it.descend(top.node.children[top.index], 0)
+			break
+		}
+		if top.index+1 < len(top.node.entries) {
+			top.index++
+			break
+		}
+		// 叶子已经迭代完，向上找到下一个entry
+		it.path = it.path[:len(it.path)-1]
+		for len(it.path) > 0 {
+			top := &it.path[len(it.path)-1]
+			if top.index < len(top.node.entries) {
+				break
+			}
+			it.path = it.path[:len(it.path)-1]
+		}
+	}
+	if it.err != nil || len(it.path) == 0 {
+		it.End()
+		return false
+	}
+	it.position = between
+	return true
+}
+
+func (it *Iterator) Prev() bool {
+	switch it.position {
+	case begin:
+		return false
+	case end:
+		it.path = it.path[:0]
+		it.descend(it.tree.meta.root, 1)
+	case between:
+		top := &it.path[len(it.path)-1]
+		if !top.node.isLeaf() {
+			// 进入entry左侧的孩子，再一直向右
+			it.descend(top.node.children[top.index], 1)
+			break
+		}
+		if top.index > 0 {
+			top.index--
+			break
+		}
+		it.path = it.path[:len(it.path)-1]
+		for len(it.path) > 0 {
+			top := &it.path[len(it.path)-1]
+			if top.index > 0 {
+				top.index--
+				break
+			}
+			it.path = it.path[:len(it.path)-1]
+		}
+	}
+	if it.err != nil || len(it.path) == 0 {
+		it.Begin()
+		return false
+	}
+	it.position = between
+	return true
+}
+
+func (it *Iterator) Key() interface{} {
+	if len(it.path) == 0 {
+		return nil
+	}
+	top := it.path[len(it.path)-1]
+	return top.node.entries[top.index].key
+}
+
+func (it *Iterator) Value() interface{} {
+	if len(it.path) == 0 {
+		return nil
+	}
+	top := it.path[len(it.path)-1]
+	return top.node.entries[top.index].value
+}
+
+// Err returns the first I/O error met by the iterator.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+func (it *Iterator) Begin() {
+	it.path = it.path[:0]
+	it.position = begin
+}
+
+func (it *Iterator) End() {
+	it.path = it.path[:0]
+	it.position = end
+}
+
+func (it *Iterator) First() bool {
+	it.Begin()
+	return it.Next()
+}
+
+func (it *Iterator) Last() bool {
+	it.End()
+	return it.Prev()
+}
+
+// descend从page id开始向最左(d==0)或最右(d==1)的叶子走，记录路径
+func (it *Iterator) descend(id PageID, d int) {
+	for id != 0 {
+		n, err := it.tree.load(id)
+		if err != nil {
+			it.err = err
+			return
+		}
+		index := 0
+		if d == 1 {
+			index = len(n.children) - 1
+			if n.isLeaf() {
+				index = len(n.entries) - 1
+			}
+		}
+		it.path = append(it.path, frame{node: n, index: index})
+		if n.isLeaf() {
+			return
+		}
+		id = n.children[index]
+	}
+}
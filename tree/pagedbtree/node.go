@@ -0,0 +1,130 @@
+package pagedbtree
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// page类型, 存储在每个page的第一个byte
+const (
+	pageTypeLeaf     byte = 1
+	pageTypeInternal byte = 2
+	pageTypeFreeList byte = 3
+)
+
+// node page布局:
+// [type 1byte][entry数目 2bytes][internal: (n+1)个孩子PageID 4bytes][n个entry: uvarint keyLen, key, uvarint valueLen, value]
+const nodeHeaderSize = 3
+
+var errCorruptPage = errors.New("corrupt page")
+
+type node struct {
+	id       PageID
+	entries  []*entry
+	children []PageID
+	dirty    bool
+}
+
+type entry struct {
+	key   interface{}
+	value interface{}
+	// 编码后的key和value, 写page时不需要重新编码
+	encodedKey   []byte
+	encodedValue []byte
+}
+
+func (n *node) isLeaf() bool {
+	return len(n.children) == 0
+}
+
+// encodedSize returns the number of bytes e takes in a page.
+func (e *entry) encodedSize() int {
+	return uvarintSize(len(e.encodedKey)) + len(e.encodedKey) + uvarintSize(len(e.encodedValue)) + len(e.encodedValue)
+}
+
+func (t *Tree) encodeNode(n *node) ([]byte, error) {
+	data := make([]byte, t.pager.PageSize())
+	data[0] = pageTypeLeaf
+	if !n.isLeaf() {
+		data[0] = pageTypeInternal
+	}
+	binary.LittleEndian.PutUint16(data[1:], uint16(len(n.entries)))
+	offset := nodeHeaderSize
+	for _, child := range n.children {
+		binary.LittleEndian.PutUint32(data[offset:], uint32(child))
+		offset += 4
+	}
+	for _, e := range n.entries {
+		if offset+e.encodedSize() > len(data) {
+			return nil, fmt.Errorf("page %d: %w", n.id, ErrEntryTooLarge)
+		}
+		offset += binary.PutUvarint(data[offset:], uint64(len(e.encodedKey)))
+		offset += copy(data[offset:], e.encodedKey)
+		offset += binary.PutUvarint(data[offset:], uint64(len(e.encodedValue)))
+		offset += copy(data[offset:], e.encodedValue)
+	}
+	return data, nil
+}
+
+func (t *Tree) decodeNode(id PageID, data []byte) (*node, error) {
+	if data[0] != pageTypeLeaf && data[0] != pageTypeInternal {
+		return nil, fmt.Errorf("page %d: %w", id, errCorruptPage)
+	}
+	count := int(binary.LittleEndian.Uint16(data[1:]))
+	offset := nodeHeaderSize
+	// 每个孩子占4bytes, 每个entry至少占2bytes
+	size := offset + 2*count
+	if data[0] == pageTypeInternal {
+		size += 4 * (count + 1)
+	}
+	if size > len(data) {
+		return nil, fmt.Errorf("page %d: %w", id, errCorruptPage)
+	}
+	n := &node{id: id, entries: make([]*entry, count)}
+	if data[0] == pageTypeInternal {
+		n.children = make([]PageID, count+1)
+		for i := range n.children {
+			n.children[i] = PageID(binary.LittleEndian.Uint32(data[offset:]))
+			offset += 4
+		}
+	}
+	for i := range n.entries {
+		var e entry
+		var err error
+		if e.encodedKey, offset, err = readBytes(data, offset); err != nil {
+			return nil, fmt.Errorf("page %d: %w", id, err)
+		}
+		if e.encodedValue, offset, err = readBytes(data, offset); err != nil {
+			return nil, fmt.Errorf("page %d: %w", id, err)
+		}
+		if e.key, err = t.keyCodec.Decode(e.encodedKey); err != nil {
+			return nil, err
+		}
+		if e.value, err = t.valueCodec.Decode(e.encodedValue); err != nil {
+			return nil, err
+		}
+		n.entries[i] = &e
+	}
+	return n, nil
+}
+
+func readBytes(data []byte, offset int) ([]byte, int, error) {
+	if offset >= len(data) {
+		return nil, 0, errCorruptPage
+	}
+	length, n := binary.Uvarint(data[offset:])
+	if n <= 0 || length > uint64(len(data)-offset-n) {
+		return nil, 0, errCorruptPage
+	}
+	offset += n
+	return append([]byte(nil), data[offset:offset+int(length)]...), offset + int(length), nil
+}
+
+func uvarintSize(x int) int {
+	size := 1
+	for ; x >= 0x80; x >>= 7 {
+		size++
+	}
+	return size
+}
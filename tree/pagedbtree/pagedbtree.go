@@ -0,0 +1,553 @@
+// Package pagedbtree implements a B-tree stored in fixed-size pages behind a pluggable Pager,
+// so that trees larger than memory can be kept in a single local file.
+//
+// Every node of the tree maps to one page. Pages are accessed through a write-back LRU cache,
+// changes reach the pager when pages are evicted and on Sync or Close.
+//
+// Pages referenced by the last synced metadata are never written in place: a node modified after a Sync
+// is copied to a new page, and the pages freed after a Sync are reused only after the next one.
+// The metadata alternates between two checksummed pages, so after a crash, even one that tears
+// the metadata page being written, the tree reopens as of the last successful Sync or Close.
+package pagedbtree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+
+	"github.com/morganxf/algorithm/tree/internal/btreecore"
+	"github.com/morganxf/algorithm/util"
+)
+
+const (
+	// page 0和1交替保存meta, node从page 2开始
+	metaPages = 2
+	metaSize  = 48
+	magic     = "PGBTREE2"
+
+	defaultOrder     = 32
+	defaultCacheSize = 64
+)
+
+var (
+	// ErrEntryTooLarge is returned by Put when an encoded key value pair cannot fit into a page.
+	ErrEntryTooLarge = errors.New("entry too large for page")
+	// ErrInvalidFile is returned by Open when the storage does not hold a tree with the same page size.
+	ErrInvalidFile = errors.New("invalid paged btree file")
+	// ErrClosed is returned when using a tree after Close.
+	ErrClosed = errors.New("paged btree is closed")
+)
+
+// Options configures Open. Comparator, KeyCodec and ValueCodec are required.
+type Options struct {
+	Comparator util.Comparator
+	KeyCodec   Codec
+	ValueCodec Codec
+	// Order is the maximum number of children of a node. It is only used when the storage is empty,
+	// an existing tree keeps the order it was created with. Defaults to 32.
+	Order int
+	// CacheSize is the number of pages kept in memory. Defaults to 64.
+	CacheSize int
+}
+
+type Tree struct {
+	Comparator util.Comparator
+	pager      Pager
+	cache      *cache
+	keyCodec   Codec
+	valueCodec Codec
+	m          int
+	meta       meta
+	// 当前修改操作中加载的node, 保证同一page只对应一个node
+	nodes map[PageID]*node
+	// 当前修改操作中分配和释放的page, 操作成功后才生效
+	allocated []PageID
+	freed     []PageID
+	// freePages中的page可以立即复用; pendingPages中的page在上次Sync之后释放, 仍被上次Sync的meta引用, 下次Sync之后才能复用
+	freePages    []PageID
+	pendingPages []PageID
+	// fresh为上次Sync之后分配的page, 不被上次Sync的meta引用, 可以原地修改
+	fresh map[PageID]bool
+	// 保存空闲page列表的page, 被上次Sync的meta引用
+	freeListPages []PageID
+	closed        bool
+}
+
+// meta is the content of the meta pages, the valid one with the larger txid is current.
+type meta struct {
+	root      PageID // 0 表示空树
+	size      int
+	pageCount PageID // 已分配的page数目，包括meta page
+	freeList  PageID // 第一个空闲page列表page，0 表示没有空闲page
+	txid      uint64 // Sync的次数, 保存在page txid%2
+}
+
+// Open loads the tree stored in pager, or initializes a new one if the pager is empty.
+func Open(pager Pager, options Options) (*Tree, error) {
+	if options.Comparator == nil || options.KeyCodec == nil || options.ValueCodec == nil {
+		return nil, errors.New("comparator, key codec and value codec are required")
+	}
+	if options.Order == 0 {
+		options.Order = defaultOrder
+	}
+	if options.CacheSize <= 0 {
+		options.CacheSize = defaultCacheSize
+	}
+	t := &Tree{
+		Comparator: options.Comparator,
+		pager:      pager,
+		cache:      newCache(pager, options.CacheSize),
+		keyCodec:   options.KeyCodec,
+		valueCodec: options.ValueCodec,
+		m:          options.Order,
+		meta:       meta{pageCount: metaPages},
+		fresh:      make(map[PageID]bool),
+	}
+	if pager.PageSize() < metaSize {
+		return nil, fmt.Errorf("page size %d is smaller than %d", pager.PageSize(), metaSize)
+	}
+	count, err := pager.PageCount()
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		if err := t.readMeta(); err != nil {
+			return nil, err
+		}
+	}
+	if t.m < 3 {
+		return nil, errors.New("invalid order, should be at least 3")
+	}
+	if t.maxEntrySize() < 2 {
+		return nil, fmt.Errorf("order %d is too large for page size %d", t.m, pager.PageSize())
+	}
+	return t, nil
+}
+
+// OpenFile opens the tree stored in the file at path, creating the file if needed.
+func OpenFile(path string, pageSize int, options Options) (*Tree, error) {
+	pager, err := NewFilePager(path, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	t, err := Open(pager, options)
+	if err != nil {
+		pager.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// Sync writes every modified page and the free page list to the pager and flushes it to durable storage,
+// then writes and flushes the metadata. A crash before the metadata is durable leaves the previous Sync intact.
+func (t *Tree) Sync() error {
+	if t.closed {
+		return ErrClosed
+	}
+	// Sync之后freePages和pendingPages中的page都可以复用, 上次的空闲page列表也不再被引用
+	free := make([]PageID, 0, len(t.freePages)+len(t.pendingPages)+len(t.freeListPages))
+	free = append(append(append(free, t.freePages...), t.pendingPages...), t.freeListPages...)
+	synced := t.meta
+	synced.txid++
+	// 空闲page列表写到新分配的page上, 失败时不修改meta, 这些page在重试时被再次使用
+	freeListPages, pages := t.encodeFreeList(free, synced.pageCount)
+	synced.pageCount += PageID(len(freeListPages))
+	if len(freeListPages) > 0 {
+		synced.freeList = freeListPages[0]
+	} else {
+		synced.freeList = 0
+	}
+	for i, id := range freeListPages {
+		t.cache.put(id, pages[i])
+	}
+	if err := t.cache.flush(); err != nil {
+		return err
+	}
+	if err := t.pager.Sync(); err != nil {
+		return err
+	}
+	if err := t.pager.WritePage(PageID(synced.txid%metaPages), t.encodeMeta(synced)); err != nil {
+		return err
+	}
+	if err := t.pager.Sync(); err != nil {
+		return err
+	}
+	t.meta = synced
+	t.freePages, t.pendingPages, t.freeListPages = free, nil, freeListPages
+	t.fresh = make(map[PageID]bool)
+	return nil
+}
+
+// Close syncs the tree and closes the pager.
+func (t *Tree) Close() error {
+	if t.closed {
+		return ErrClosed
+	}
+	if err := t.Sync(); err != nil {
+		return err
+	}
+	t.closed = true
+	return t.pager.Close()
+}
+
+func (t *Tree) Put(key interface{}, value interface{}) error {
+	e, err := t.newEntry(key, value)
+	if err != nil {
+		return err
+	}
+	return t.update(func() error {
+		if t.meta.root == 0 {
+			root, err := t.newNode()
+			if err != nil {
+				return err
+			}
+			root.entries = []*entry{e}
+			t.meta.root = root.id
+			t.meta.size++
+			return nil
+		}
+		root, err := t.load(t.meta.root)
+		if err != nil {
+			return err
+		}
+		root, inserted, err := t.core().Insert(root, e)
+		if err != nil {
+			return err
+		}
+		if inserted {
+			t.meta.size++
+		}
+		t.meta.root = root.id
+		return nil
+	})
+}
+
+func (t *Tree) Get(key interface{}) (value interface{}, found bool, err error) {
+	if t.closed {
+		return nil, false, ErrClosed
+	}
+	for id := t.meta.root; id != 0; {
+		n, err := t.load(id)
+		if err != nil {
+			return nil, false, err
+		}
+		index, found := t.search(n, key)
+		if found {
+			return n.entries[index].value, true, nil
+		}
+		if n.isLeaf() {
+			break
+		}
+		id = n.children[index]
+	}
+	return nil, false, nil
+}
+
+func (t *Tree) Remove(key interface{}) error {
+	return t.update(func() error {
+		if t.meta.root == 0 {
+			return nil
+		}
+		root, err := t.load(t.meta.root)
+		if err != nil {
+			return err
+		}
+		root, removed, err := t.core().Delete(root, key)
+		if err != nil {
+			return err
+		}
+		if removed {
+			t.meta.size--
+		}
+		t.meta.root = 0
+		if root != nil {
+			t.meta.root = root.id
+		}
+		return nil
+	})
+}
+
+func (t *Tree) Empty() bool {
+	return t.meta.size == 0
+}
+
+func (t *Tree) Size() int {
+	return t.meta.size
+}
+
+func (t *Tree) Keys() ([]interface{}, error) {
+	keys := make([]interface{}, 0, t.meta.size)
+	it := t.Iterator()
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys, it.Err()
+}
+
+func (t *Tree) Values() ([]interface{}, error) {
+	values := make([]interface{}, 0, t.meta.size)
+	it := t.Iterator()
+	for it.Next() {
+		values = append(values, it.Value())
+	}
+	return values, it.Err()
+}
+
+// Clear removes every entry. The pages of the file are reused by later writes.
+func (t *Tree) Clear() error {
+	if t.closed {
+		return ErrClosed
+	}
+	unused := make(map[PageID]bool)
+	for _, ids := range [][]PageID{t.freePages, t.pendingPages, t.freeListPages} {
+		for _, id := range ids {
+			unused[id] = true
+		}
+	}
+	for id := PageID(metaPages); id < t.meta.pageCount; id++ {
+		if !unused[id] {
+			t.release(id)
+		}
+	}
+	t.meta.root = 0
+	t.meta.size = 0
+	return nil
+}
+
+func (t *Tree) Height() (int, error) {
+	height := 0
+	for id := t.meta.root; id != 0; height++ {
+		n, err := t.load(id)
+		if err != nil {
+			return 0, err
+		}
+		if n.isLeaf() {
+			return height + 1, nil
+		}
+		id = n.children[0]
+	}
+	return height, nil
+}
+
+func (t *Tree) maxChildren() int {
+	return t.m
+}
+
+func (t *Tree) minChildren() int {
+	// ceil(m/2)
+	return (t.m + 1) / 2
+}
+
+func (t *Tree) maxEntries() int {
+	return t.maxChildren() - 1
+}
+
+func (t *Tree) minEntries() int {
+	return t.minChildren() - 1
+}
+
+// maxEntrySize保证有maxEntries个entry和maxChildren个孩子的node可以放入一个page
+func (t *Tree) maxEntrySize() int {
+	return (t.pager.PageSize() - nodeHeaderSize - 4*t.maxChildren()) / t.maxEntries()
+}
+
+func (t *Tree) newEntry(key interface{}, value interface{}) (*entry, error) {
+	encodedKey, err := t.keyCodec.Encode(key)
+	if err != nil {
+		return nil, err
+	}
+	encodedValue, err := t.valueCodec.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+	e := &entry{key: key, value: value, encodedKey: encodedKey, encodedValue: encodedValue}
+	if e.encodedSize() > t.maxEntrySize() {
+		return nil, ErrEntryTooLarge
+	}
+	return e, nil
+}
+
+// update执行一次修改操作. fn只修改内存中的node, 成功后把修改过的node复制到新的page(copy-on-write)并放入cache,
+// 释放的page此时才加入空闲列表; 失败时恢复meta并撤销page的分配, cache没有任何修改
+func (t *Tree) update(fn func() error) error {
+	if t.closed {
+		return ErrClosed
+	}
+	saved := t.meta
+	t.nodes = make(map[PageID]*node)
+	t.allocated, t.freed = t.allocated[:0], t.freed[:0]
+	defer func() {
+		t.nodes = nil
+	}()
+	err := fn()
+	var ids []PageID
+	var pages [][]byte
+	if err == nil {
+		if t.meta.root != 0 {
+			t.meta.root = t.relocate(t.meta.root)
+		}
+		ids, pages, err = t.encodeNodes()
+	}
+	if err != nil {
+		t.rollback(saved)
+		return err
+	}
+	for _, id := range t.freed {
+		t.release(id)
+	}
+	for i, id := range ids {
+		t.cache.put(id, pages[i])
+	}
+	return nil
+}
+
+func (t *Tree) encodeNodes() ([]PageID, [][]byte, error) {
+	var ids []PageID
+	var pages [][]byte
+	for _, n := range t.nodes {
+		if !n.dirty {
+			continue
+		}
+		data, err := t.encodeNode(n)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids = append(ids, n.id)
+		pages = append(pages, data)
+	}
+	return ids, pages, nil
+}
+
+func (t *Tree) load(id PageID) (*node, error) {
+	if n, ok := t.nodes[id]; ok {
+		return n, nil
+	}
+	data, err := t.cache.get(id)
+	if err != nil {
+		return nil, err
+	}
+	n, err := t.decodeNode(id, data)
+	if err != nil {
+		return nil, err
+	}
+	if t.nodes != nil {
+		t.nodes[id] = n
+	}
+	return n, nil
+}
+
+// newNode分配一个page作为新的node
+func (t *Tree) newNode() (*node, error) {
+	n := &node{id: t.allocate(), dirty: true}
+	t.nodes[n.id] = n
+	return n, nil
+}
+
+// free释放node的page, 修改操作成功后才加入空闲列表
+func (t *Tree) free(n *node) error {
+	delete(t.nodes, n.id)
+	t.freed = append(t.freed, n.id)
+	return nil
+}
+
+// core返回在page中的node上执行插入和删除的btreecore.Tree
+func (t *Tree) core() btreecore.Tree[*node, PageID, *entry] {
+	return btreecore.Tree[*node, PageID, *entry]{Nodes: pages{t}, Comparator: t.Comparator, M: t.m}
+}
+
+func (t *Tree) search(n *node, key interface{}) (index int, found bool) {
+	return t.core().Search(n, key)
+}
+
+// pages让btreecore通过load访问page中的node, 修改过的node标记为dirty, 在update结束时写回
+type pages struct {
+	t *Tree
+}
+
+func (p pages) Key(e *entry) interface{} {
+	return e.key
+}
+
+func (p pages) Entries(n *node) []*entry {
+	return n.entries
+}
+
+func (p pages) SetEntries(n *node, entries []*entry) {
+	n.entries = entries
+	n.dirty = true
+}
+
+func (p pages) Children(n *node) []PageID {
+	return n.children
+}
+
+func (p pages) SetChildren(n *node, children []PageID) {
+	n.children = children
+	n.dirty = true
+}
+
+func (p pages) Child(n *node, index int) (*node, error) {
+	return p.t.load(n.children[index])
+}
+
+func (p pages) Ref(n *node) PageID {
+	return n.id
+}
+
+func (p pages) NewNode() (*node, error) {
+	return p.t.newNode()
+}
+
+func (p pages) Free(n *node) error {
+	return p.t.free(n)
+}
+
+// meta page布局: [magic 8bytes][pageSize 4][order 4][root 4][size 8][pageCount 4][freeList 4][txid 8][crc32 4]
+func (t *Tree) encodeMeta(m meta) []byte {
+	data := make([]byte, t.pager.PageSize())
+	copy(data, magic)
+	binary.LittleEndian.PutUint32(data[8:], uint32(t.pager.PageSize()))
+	binary.LittleEndian.PutUint32(data[12:], uint32(t.m))
+	binary.LittleEndian.PutUint32(data[16:], uint32(m.root))
+	binary.LittleEndian.PutUint64(data[20:], uint64(m.size))
+	binary.LittleEndian.PutUint32(data[28:], uint32(m.pageCount))
+	binary.LittleEndian.PutUint32(data[32:], uint32(m.freeList))
+	binary.LittleEndian.PutUint64(data[36:], m.txid)
+	binary.LittleEndian.PutUint32(data[44:], crc32.ChecksumIEEE(data[:44]))
+	return data
+}
+
+// readMeta从两个meta page中选择校验通过且txid较大的一个, 再读取空闲page列表
+func (t *Tree) readMeta() error {
+	found := false
+	data := make([]byte, t.pager.PageSize())
+	for id := PageID(0); id < metaPages; id++ {
+		if err := t.pager.ReadPage(id, data); errors.Is(err, ErrPageNotFound) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if !bytes.Equal(data[:len(magic)], []byte(magic)) || int(binary.LittleEndian.Uint32(data[8:])) != t.pager.PageSize() ||
+			binary.LittleEndian.Uint32(data[44:]) != crc32.ChecksumIEEE(data[:44]) {
+			continue
+		}
+		m := meta{
+			root:      PageID(binary.LittleEndian.Uint32(data[16:])),
+			size:      int(binary.LittleEndian.Uint64(data[20:])),
+			pageCount: PageID(binary.LittleEndian.Uint32(data[28:])),
+			freeList:  PageID(binary.LittleEndian.Uint32(data[32:])),
+			txid:      binary.LittleEndian.Uint64(data[36:]),
+		}
+		if !found || m.txid > t.meta.txid {
+			t.meta = m
+			t.m = int(binary.LittleEndian.Uint32(data[12:]))
+			found = true
+		}
+	}
+	if !found {
+		return ErrInvalidFile
+	}
+	return t.readFreeList()
+}
@@ -0,0 +1,561 @@
+package pagedbtree
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func newIntTree(t *testing.T, pager Pager, order int, cacheSize int) *Tree {
+	tree, err := Open(pager, Options{
+		Comparator: util.IntComparator,
+		KeyCodec:   IntCodec{},
+		ValueCodec: StringCodec{},
+		Order:      order,
+		CacheSize:  cacheSize,
+	})
+	if err != nil {
+		t.Fatalf("Got error %v", err)
+	}
+	return tree
+}
+
+func TestPagedBTreePutGetRemove(t *testing.T) {
+	tree := newIntTree(t, NewMemoryPager(256), 3, 4)
+	for _, key := range []int{7, 9, 10, 6, 3, 4, 5, 8, 2, 1} {
+		if err := tree.Put(key, fmt.Sprint(key)); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+	tree.Put(1, "a") // overwrite
+	assertValidTree(t, tree, 10)
+
+	tests := [][]interface{}{
+		{0, nil, false},
+		{1, "a", true},
+		{5, "5", true},
+		{10, "10", true},
+		{11, nil, false},
+	}
+	for _, test := range tests {
+		if value, found, err := tree.Get(test[0]); value != test[1] || found != test[2] || err != nil {
+			t.Errorf("Got %v,%v,%v expected %v,%v", value, found, err, test[1], test[2])
+		}
+	}
+
+	for _, key := range []int{5, 1, 10, 3, 11} {
+		if err := tree.Remove(key); err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+	assertValidTree(t, tree, 6)
+	if keys, _ := tree.Keys(); fmt.Sprint(keys) != "[2 4 6 7 8 9]" {
+		t.Errorf("Got %v expected %v", keys, "[2 4 6 7 8 9]")
+	}
+	for _, key := range []int{2, 4, 6, 7, 8, 9} {
+		tree.Remove(key)
+	}
+	assertValidTree(t, tree, 0)
+	if height, _ := tree.Height(); height != 0 {
+		t.Errorf("Got %v expected %v", height, 0)
+	}
+}
+
+func TestPagedBTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(13))
+	for _, order := range []int{3, 4, 7} {
+		tree := newIntTree(t, NewMemoryPager(512), order, 8)
+		present := map[int]string{}
+		for i := 0; i < 3000; i++ {
+			key := r.Intn(500)
+			if r.Intn(2) == 0 {
+				if err := tree.Remove(key); err != nil {
+					t.Fatalf("Got error %v", err)
+				}
+				delete(present, key)
+			} else {
+				value := fmt.Sprint(r.Intn(1000))
+				if err := tree.Put(key, value); err != nil {
+					t.Fatalf("Got error %v", err)
+				}
+				present[key] = value
+			}
+			if i%250 == 0 {
+				assertValidTree(t, tree, len(present))
+			}
+		}
+		assertValidTree(t, tree, len(present))
+		for key, expectedValue := range present {
+			if value, found, err := tree.Get(key); value != expectedValue || !found || err != nil {
+				t.Errorf("Got %v,%v,%v expected %v,%v", value, found, err, expectedValue, true)
+			}
+		}
+	}
+}
+
+func TestPagedBTreeReopen(t *testing.T) {
+	pager := NewMemoryPager(512)
+	tree := newIntTree(t, pager, 5, 2)
+	for i := 0; i < 200; i++ {
+		tree.Put(i, strings.Repeat("v", i%10))
+	}
+	for i := 0; i < 200; i += 3 {
+		tree.Remove(i)
+	}
+	if err := tree.Close(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if err := tree.Put(1, "x"); err != ErrClosed {
+		t.Errorf("Got %v expected %v", err, ErrClosed)
+	}
+
+	// order不同于文件中保存的order时，使用文件中的order
+	tree = newIntTree(t, pager, 9, 2)
+	assertValidTree(t, tree, 133)
+	if actualValue, expectedValue := tree.m, 5; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if value, found, _ := tree.Get(8); value != "vvvvvvvv" || !found {
+		t.Errorf("Got %v,%v expected %v,%v", value, found, "vvvvvvvv", true)
+	}
+	if _, found, _ := tree.Get(9); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+}
+
+func TestPagedBTreeFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pagedbtree")
+	if err != nil {
+		t.Fatalf("Got error %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "tree.db")
+	options := Options{Comparator: util.StringComparator, KeyCodec: StringCodec{}, ValueCodec: IntCodec{}, Order: 8}
+
+	tree, err := OpenFile(path, 1024, options)
+	if err != nil {
+		t.Fatalf("Got error %v", err)
+	}
+	for i := 0; i < 1000; i++ {
+		if err := tree.Put(fmt.Sprintf("key%04d", i), i); err != nil {
+			t.Fatalf("Got error %v", err)
+		}
+	}
+	if err := tree.Close(); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+
+	tree, err = OpenFile(path, 1024, options)
+	if err != nil {
+		t.Fatalf("Got error %v", err)
+	}
+	if actualValue, expectedValue := tree.Size(), 1000; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if value, found, err := tree.Get("key0500"); value != 500 || !found || err != nil {
+		t.Errorf("Got %v,%v,%v expected %v,%v", value, found, err, 500, true)
+	}
+	tree.Close()
+
+	if _, err := OpenFile(path, 512, options); err != ErrInvalidFile {
+		t.Errorf("Got %v expected %v", err, ErrInvalidFile)
+	}
+}
+
+func TestPagedBTreeEntryTooLarge(t *testing.T) {
+	tree := newIntTree(t, NewMemoryPager(128), 4, 4)
+	if err := tree.Put(1, strings.Repeat("x", 100)); !errors.Is(err, ErrEntryTooLarge) {
+		t.Errorf("Got %v expected %v", err, ErrEntryTooLarge)
+	}
+	if actualValue := tree.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	options := Options{Comparator: util.IntComparator, KeyCodec: IntCodec{}, ValueCodec: StringCodec{}, Order: 32}
+	if _, err := Open(NewMemoryPager(64), options); err == nil {
+		t.Errorf("Expected error for an order too large for the page size")
+	}
+	options.Comparator = nil
+	if _, err := Open(NewMemoryPager(4096), options); err == nil {
+		t.Errorf("Expected error for a nil comparator")
+	}
+}
+
+func TestPagedBTreeCorruptPage(t *testing.T) {
+	tree := newIntTree(t, NewMemoryPager(128), 4, 4)
+	tests := [][]byte{
+		{pageTypeLeaf, 0xff, 0xff},
+		{pageTypeInternal, 30, 0},
+		{pageTypeLeaf, 1, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01},
+		{pageTypeLeaf, 63, 0},
+		{4},
+	}
+	for _, test := range tests {
+		data := make([]byte, 128)
+		copy(data, test)
+		if _, err := tree.decodeNode(1, data); !errors.Is(err, errCorruptPage) {
+			t.Errorf("Got %v expected %v for %v", err, errCorruptPage, test)
+		}
+	}
+}
+
+func TestPagedBTreeFilePagerErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pagedbtree")
+	if err != nil {
+		t.Fatalf("Got error %v", err)
+	}
+	defer os.RemoveAll(dir)
+	pager, err := NewFilePager(filepath.Join(dir, "pages"), 64)
+	if err != nil {
+		t.Fatalf("Got error %v", err)
+	}
+	data := make([]byte, 64)
+	pager.WritePage(0, data)
+	pager.file.WriteAt([]byte{1}, 64)
+
+	if err := pager.ReadPage(0, data); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if err := pager.ReadPage(1, data); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Got %v expected %v", err, io.ErrUnexpectedEOF)
+	}
+	if err := pager.ReadPage(2, data); !errors.Is(err, ErrPageNotFound) {
+		t.Errorf("Got %v expected %v", err, ErrPageNotFound)
+	}
+	pager.Close()
+	if err := pager.ReadPage(0, data); err == nil || errors.Is(err, ErrPageNotFound) {
+		t.Errorf("Got %v expected the error of the closed file", err)
+	}
+}
+
+func TestPagedBTreeClear(t *testing.T) {
+	pager := NewMemoryPager(256)
+	tree := newIntTree(t, pager, 3, 4)
+	for i := 0; i < 50; i++ {
+		tree.Put(i, "a")
+	}
+	tree.Sync()
+	tree.Clear()
+	assertValidTree(t, tree, 0)
+	tree.Put(1, "b")
+	tree.Close()
+
+	tree = newIntTree(t, pager, 3, 4)
+	assertValidTree(t, tree, 1)
+}
+
+func TestPagedBTreeIterator(t *testing.T) {
+	tree := newIntTree(t, NewMemoryPager(256), 3, 3)
+	it := tree.Iterator()
+	for it.Next() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+	for it.Prev() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+
+	for i := 1; i <= 20; i++ {
+		tree.Put(i, fmt.Sprint(i))
+	}
+	it = tree.Iterator()
+	count := 0
+	for it.Next() {
+		count++
+		if actualValue, expectedValue := it.Key(), count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := it.Value(), fmt.Sprint(count); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue, expectedValue := count, 20; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for it.Prev() {
+		if actualValue, expectedValue := it.Key(), count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		count--
+	}
+	if actualValue, expectedValue := count, 0; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// Next和Prev交替
+	it.First()
+	for i := 1; i < 20; i++ {
+		it.Next()
+		it.Prev()
+		if actualValue, expectedValue := it.Key(), i; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		it.Next()
+	}
+	if actualValue, expectedValue := it.Last(), true; actualValue != expectedValue || it.Key() != 20 {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), expectedValue, 20)
+	}
+	if it.Err() != nil {
+		t.Errorf("Got error %v", it.Err())
+	}
+}
+
+// assertValidTree checks node occupancy, key order, leaf depth and that every page is either reachable or free.
+// crashPager keeps the pages as of the last Sync apart from the pages written since.
+// crash returns the storage as a crash would leave it: any subset of the writes since the last Sync,
+// some of them torn, on top of the synced pages.
+type crashPager struct {
+	*MemoryPager
+	synced   *MemoryPager
+	writes   []PageID
+	failSync int // Sync在第failSync次调用时失败, 0表示不失败
+}
+
+func newCrashPager(pageSize int) *crashPager {
+	return &crashPager{MemoryPager: NewMemoryPager(pageSize), synced: NewMemoryPager(pageSize)}
+}
+
+func (p *crashPager) WritePage(id PageID, data []byte) error {
+	p.writes = append(p.writes, id)
+	return p.MemoryPager.WritePage(id, data)
+}
+
+func (p *crashPager) Sync() error {
+	if p.failSync--; p.failSync == 0 {
+		return errors.New("sync failed")
+	}
+	for id, data := range p.MemoryPager.pages {
+		p.synced.WritePage(PageID(id), data)
+	}
+	p.writes = nil
+	return nil
+}
+
+func (p *crashPager) crash(r *rand.Rand) *MemoryPager {
+	crashed := NewMemoryPager(p.pageSize)
+	for id, data := range p.synced.pages {
+		crashed.WritePage(PageID(id), data)
+	}
+	for _, id := range p.writes {
+		data := append([]byte(nil), p.MemoryPager.pages[id]...)
+		switch r.Intn(3) {
+		case 0:
+			continue
+		case 1:
+			// 只写入了前半部分
+			previous := make([]byte, p.pageSize)
+			crashed.ReadPage(id, previous)
+			copy(data[p.pageSize/2:], previous[p.pageSize/2:])
+		}
+		crashed.WritePage(id, data)
+	}
+	return crashed
+}
+
+func assertTreeContent(t *testing.T, tree *Tree, expected map[int]string) {
+	assertValidTree(t, tree, len(expected))
+	for key, expectedValue := range expected {
+		if value, found, err := tree.Get(key); value != expectedValue || !found || err != nil {
+			t.Errorf("Got %v,%v,%v expected %v for key %v", value, found, err, expectedValue, key)
+			return
+		}
+	}
+}
+
+func TestPagedBTreeCrash(t *testing.T) {
+	for seed := int64(0); seed < 30; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		pager := newCrashPager(256)
+		tree := newIntTree(t, pager, 4, 3)
+		current, synced := map[int]string{}, map[int]string{}
+		for round := 0; round < 4; round++ {
+			// cache很小, Sync之间会有大量page被淘汰写回
+			for i := 0; i < 150; i++ {
+				key := r.Intn(200)
+				if r.Intn(3) == 0 {
+					tree.Remove(key)
+					delete(current, key)
+				} else {
+					value := strings.Repeat("v", r.Intn(8))
+					tree.Put(key, value)
+					current[key] = value
+				}
+			}
+			if round < 3 {
+				if err := tree.Sync(); err != nil {
+					t.Fatalf("Got error %v", err)
+				}
+				synced = map[int]string{}
+				for key, value := range current {
+					synced[key] = value
+				}
+			}
+		}
+
+		tree = newIntTree(t, pager.crash(r), 4, 3)
+		assertTreeContent(t, tree, synced)
+		// 恢复后的空闲列表可以继续使用
+		for i := 0; i < 100; i++ {
+			tree.Put(1000+i, "x")
+			synced[1000+i] = "x"
+		}
+		assertTreeContent(t, tree, synced)
+	}
+}
+
+func TestPagedBTreeCrashDuringSync(t *testing.T) {
+	for seed := int64(0); seed < 30; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		pager := newCrashPager(256)
+		tree := newIntTree(t, pager, 4, 3)
+		before := map[int]string{}
+		for i := 0; i < 100; i++ {
+			tree.Put(i, "a")
+			before[i] = "a"
+		}
+		tree.Sync()
+		after := map[int]string{}
+		for i := 50; i < 150; i++ {
+			tree.Put(i, "b")
+		}
+		for i := 0; i < 150; i++ {
+			if i < 50 {
+				after[i] = "a"
+			} else {
+				after[i] = "b"
+			}
+		}
+		// page已经持久化, meta写入之后的Sync失败, meta可能没写, 写了一部分或者写完整
+		pager.failSync = 2
+		if err := tree.Sync(); err == nil {
+			t.Fatalf("Expected sync error")
+		}
+
+		tree = newIntTree(t, pager.crash(r), 4, 3)
+		if tree.Size() == len(before) {
+			assertTreeContent(t, tree, before)
+		} else {
+			assertTreeContent(t, tree, after)
+		}
+	}
+}
+
+// failingPager fails every read once reads reaches 0.
+type failingPager struct {
+	*MemoryPager
+	reads int
+}
+
+func (p *failingPager) ReadPage(id PageID, data []byte) error {
+	if p.reads == 0 {
+		return errors.New("read failed")
+	}
+	p.reads--
+	return p.MemoryPager.ReadPage(id, data)
+}
+
+func TestPagedBTreeUpdateError(t *testing.T) {
+	pager := &failingPager{MemoryPager: NewMemoryPager(256), reads: -1}
+	tree := newIntTree(t, pager, 4, 2)
+	expected := map[int]string{}
+	for i := 0; i < 300; i++ {
+		tree.Put(i, "a")
+		expected[i] = "a"
+	}
+	tree.Sync()
+
+	r := rand.New(rand.NewSource(1))
+	failures := 0
+	for i := 0; i < 300; i++ {
+		key := r.Intn(300)
+		// 在删除过程中的第几次读取失败
+		pager.reads = r.Intn(6)
+		err := tree.Remove(key)
+		pager.reads = -1
+		if err != nil {
+			failures++
+		} else {
+			delete(expected, key)
+		}
+		if i%30 == 0 {
+			tree.Sync()
+		}
+	}
+	if failures == 0 {
+		t.Errorf("Expected some removes to fail")
+	}
+	assertTreeContent(t, tree, expected)
+	// 失败的删除没有释放仍被使用的page, 后续写入不会覆盖已有的数据
+	for i := 300; i < 600; i++ {
+		tree.Put(i, "b")
+		expected[i] = "b"
+	}
+	assertTreeContent(t, tree, expected)
+}
+
+func assertValidTree(t *testing.T, tree *Tree, expectedSize int) {
+	if actualValue, expectedValue := tree.Size(), expectedSize; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v for tree size", actualValue, expectedValue)
+	}
+	keys, err := tree.Keys()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if len(keys) != expectedSize {
+		t.Errorf("Got %v keys expected %v", len(keys), expectedSize)
+	}
+	for i := 1; i < len(keys); i++ {
+		if tree.Comparator(keys[i-1], keys[i]) >= 0 {
+			t.Errorf("Keys out of order: %v", keys)
+			break
+		}
+	}
+
+	pages := map[PageID]bool{}
+	height, _ := tree.Height()
+	var check func(id PageID, depth int)
+	check = func(id PageID, depth int) {
+		pages[id] = true
+		n, err := tree.load(id)
+		if err != nil {
+			t.Errorf("Got error %v", err)
+			return
+		}
+		if id != tree.meta.root && len(n.entries) < tree.minEntries() || len(n.entries) == 0 || len(n.entries) > tree.maxEntries() {
+			t.Errorf("Got %v entries in page %v", len(n.entries), id)
+		}
+		if n.isLeaf() {
+			if depth != height {
+				t.Errorf("Got leaf depth %v expected %v", depth, height)
+			}
+			return
+		}
+		if len(n.children) != len(n.entries)+1 {
+			t.Errorf("Got %v children for %v entries", len(n.children), len(n.entries))
+		}
+		for _, child := range n.children {
+			check(child, depth+1)
+		}
+	}
+	if tree.meta.root != 0 {
+		check(tree.meta.root, 1)
+	}
+	// 每个page要么被使用, 要么在空闲列表中, 不能同时出现两次
+	for _, ids := range [][]PageID{tree.freePages, tree.pendingPages, tree.freeListPages} {
+		for _, id := range ids {
+			if pages[id] {
+				t.Errorf("Page %v is both used and free", id)
+			}
+			pages[id] = true
+		}
+	}
+	if actualValue, expectedValue := len(pages), int(tree.meta.pageCount)-metaPages; actualValue != expectedValue {
+		t.Errorf("Got %v pages expected %v", actualValue, expectedValue)
+	}
+}
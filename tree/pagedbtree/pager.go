@@ -0,0 +1,132 @@
+package pagedbtree
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// PageID identifies a fixed-size page. Page 0 holds the tree metadata.
+type PageID uint32
+
+// Pager reads and writes fixed-size pages of a storage.
+type Pager interface {
+	// PageSize returns the size in bytes of every page.
+	PageSize() int
+
+	// PageCount returns the number of pages currently stored.
+	PageCount() (int, error)
+
+	// ReadPage fills data, which is PageSize() bytes long, with the content of page id.
+	ReadPage(id PageID, data []byte) error
+
+	// WritePage stores data, which is PageSize() bytes long, as page id. The storage grows as needed.
+	WritePage(id PageID, data []byte) error
+
+	// Sync flushes written pages to durable storage.
+	Sync() error
+
+	// Close releases the storage. The pager must not be used afterwards.
+	Close() error
+}
+
+// ErrPageNotFound is returned when reading a page that was never written.
+var ErrPageNotFound = errors.New("page not found")
+
+// FilePager stores pages in a single local file, page id at offset id*PageSize().
+type FilePager struct {
+	file     *os.File
+	pageSize int
+}
+
+// NewFilePager opens or creates the file at path.
+func NewFilePager(path string, pageSize int) (*FilePager, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FilePager{file: file, pageSize: pageSize}, nil
+}
+
+func (p *FilePager) PageSize() int {
+	return p.pageSize
+}
+
+func (p *FilePager) PageCount() (int, error) {
+	info, err := p.file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return int(info.Size() / int64(p.pageSize)), nil
+}
+
+func (p *FilePager) ReadPage(id PageID, data []byte) error {
+	n, err := p.file.ReadAt(data, int64(id)*int64(p.pageSize))
+	switch {
+	case n == len(data):
+		return nil
+	case n == 0 && err == io.EOF:
+		return fmt.Errorf("read page %d: %w", id, ErrPageNotFound)
+	case err == io.EOF:
+		// 文件在page中间结束
+		return fmt.Errorf("read page %d: %w", id, io.ErrUnexpectedEOF)
+	default:
+		return fmt.Errorf("read page %d: %w", id, err)
+	}
+}
+
+func (p *FilePager) WritePage(id PageID, data []byte) error {
+	_, err := p.file.WriteAt(data, int64(id)*int64(p.pageSize))
+	return err
+}
+
+func (p *FilePager) Sync() error {
+	return p.file.Sync()
+}
+
+func (p *FilePager) Close() error {
+	return p.file.Close()
+}
+
+// MemoryPager keeps pages in memory. It survives Close, so a tree can be reopened on it.
+type MemoryPager struct {
+	pages    [][]byte
+	pageSize int
+}
+
+func NewMemoryPager(pageSize int) *MemoryPager {
+	return &MemoryPager{pageSize: pageSize}
+}
+
+func (p *MemoryPager) PageSize() int {
+	return p.pageSize
+}
+
+func (p *MemoryPager) PageCount() (int, error) {
+	return len(p.pages), nil
+}
+
+func (p *MemoryPager) ReadPage(id PageID, data []byte) error {
+	if int(id) >= len(p.pages) {
+		return fmt.Errorf("read page %d: %w", id, ErrPageNotFound)
+	}
+	copy(data, p.pages[id])
+	return nil
+}
+
+func (p *MemoryPager) WritePage(id PageID, data []byte) error {
+	for int(id) >= len(p.pages) {
+		p.pages = append(p.pages, make([]byte, p.pageSize))
+	}
+	copy(p.pages[id], data)
+	return nil
+}
+
+func (p *MemoryPager) Sync() error {
+	return nil
+}
+
+func (p *MemoryPager) Close() error {
+	return nil
+}
@@ -0,0 +1,250 @@
+package pairingheap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/tree/internal/heapowner"
+	"github.com/morganxf/algorithm/util"
+)
+
+func assertHeapImplementation() {
+	var _ container.Container = (*Heap)(nil)
+}
+
+// Heap is a pairing heap. Push, Meld and DecreaseKey cost O(1), Pop costs O(log n) amortized.
+type Heap struct {
+	root       *Handle
+	size       int
+	owner      *heapowner.Owner // 第一次PushHandle或Meld时创建
+	Comparator util.Comparator
+}
+
+// Handle refers to a value pushed into a Heap, it is also the node of the heap.
+type Handle struct {
+	value   interface{}
+	child   *Handle          // 第一个孩子
+	sibling *Handle          // 下一个兄弟
+	prev    *Handle          // 第一个孩子指向父亲, 其余指向上一个兄弟
+	owner   *heapowner.Owner // nil表示已经不在堆中
+}
+
+// Value returns the current value of the handle.
+func (h *Handle) Value() interface{} {
+	return h.value
+}
+
+func NewWith(comparator util.Comparator) *Heap {
+	return &Heap{Comparator: comparator}
+}
+
+func NewWithIntComparator() *Heap {
+	return NewWith(util.IntComparator)
+}
+
+func NewWithStringComparator() *Heap {
+	return NewWith(util.StringComparator)
+}
+
+func (heap *Heap) Push(values ...interface{}) {
+	for _, value := range values {
+		heap.PushHandle(value)
+	}
+}
+
+// PushHandle adds value to the heap and returns its handle for DecreaseKey and Remove.
+func (heap *Heap) PushHandle(value interface{}) *Handle {
+	handle := &Handle{value: value, owner: heap.init()}
+	heap.root = heap.link(heap.root, handle)
+	heap.size++
+	return handle
+}
+
+func (heap *Heap) Pop() (interface{}, bool) {
+	if heap.root == nil {
+		return nil, false
+	}
+	root := heap.root
+	if !heap.Remove(root) {
+		return nil, false
+	}
+	return root.value, true
+}
+
+func (heap *Heap) Peek() (interface{}, bool) {
+	if heap.root == nil {
+		return nil, false
+	}
+	return heap.root.value, true
+}
+
+// PeekHandle returns the handle of the top value.
+func (heap *Heap) PeekHandle() (*Handle, bool) {
+	if heap.root == nil {
+		return nil, false
+	}
+	return heap.root, true
+}
+
+// Contains reports whether handle is in this heap, either pushed into it or melded into it from another heap.
+func (heap *Heap) Contains(handle *Handle) bool {
+	return handle != nil && heap.owner.Owns(handle.owner)
+}
+
+// DecreaseKey replaces the value of handle with a value that is not greater than it, in O(1).
+// It returns false if the heap does not contain handle and panics if value is greater than the current value.
+func (heap *Heap) DecreaseKey(handle *Handle, value interface{}) bool {
+	if !heap.Contains(handle) {
+		return false
+	}
+	if heap.Comparator(value, handle.value) > 0 {
+		panic("Invalid key, greater than the current value")
+	}
+	handle.value = value
+	if handle != heap.root {
+		// 从父亲处剪下子树, 再与根合并
+		heap.cut(handle)
+		heap.root = heap.link(heap.root, handle)
+	}
+	return true
+}
+
+// Remove deletes the value of handle from the heap in O(log n) amortized.
+// It returns false if the heap does not contain handle.
+func (heap *Heap) Remove(handle *Handle) bool {
+	if !heap.Contains(handle) {
+		return false
+	}
+	children := handle.child
+	if handle == heap.root {
+		heap.root = nil
+	} else {
+		heap.cut(handle)
+	}
+	if children != nil {
+		children.prev = nil
+	}
+	heap.root = heap.link(heap.root, heap.mergePairs(children))
+	handle.child, handle.owner = nil, nil
+	heap.size--
+	return true
+}
+
+// Meld moves all the values of other into the heap in O(1), leaving other empty.
+// The handles of other stay valid and belong to the heap afterwards.
+func (heap *Heap) Meld(other *Heap) {
+	if other == heap || other.root == nil {
+		return
+	}
+	heap.root = heap.link(heap.root, other.root)
+	heap.size += other.size
+	other.owner.Meld(heap.init())
+	other.root, other.size, other.owner = nil, 0, nil
+}
+
+func (heap *Heap) Empty() bool {
+	return heap.size == 0
+}
+
+func (heap *Heap) Size() int {
+	return heap.size
+}
+
+// Clear removes all the values, the handles of the heap are no longer contained in it.
+func (heap *Heap) Clear() {
+	heap.root = nil
+	heap.size = 0
+	heap.owner = nil
+}
+
+// init创建零值堆的owner
+func (heap *Heap) init() *heapowner.Owner {
+	if heap.owner == nil {
+		heap.owner = heapowner.New()
+	}
+	return heap.owner
+}
+
+// Values returns the values in no particular order, the top value first.
+func (heap *Heap) Values() []interface{} {
+	values := make([]interface{}, 0, heap.size)
+	stack := []*Handle{}
+	if heap.root != nil {
+		stack = append(stack, heap.root)
+	}
+	for len(stack) > 0 {
+		handle := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		values = append(values, handle.value)
+		for child := handle.child; child != nil; child = child.sibling {
+			stack = append(stack, child)
+		}
+	}
+	return values
+}
+
+func (heap *Heap) String() string {
+	str := "PairingHeap\n"
+	values := []string{}
+	for _, value := range heap.Values() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+// link合并两棵树, 较大的根成为较小的根的第一个孩子
+func (heap *Heap) link(a *Handle, b *Handle) *Handle {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if heap.Comparator(b.value, a.value) < 0 {
+		a, b = b, a
+	}
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	a.sibling, a.prev = nil, nil
+	return a
+}
+
+// cut把handle的子树从父亲或兄弟链表中摘下
+func (heap *Heap) cut(handle *Handle) {
+	if handle.prev.child == handle {
+		handle.prev.child = handle.sibling
+	} else {
+		handle.prev.sibling = handle.sibling
+	}
+	if handle.sibling != nil {
+		handle.sibling.prev = handle.prev
+	}
+	handle.sibling, handle.prev = nil, nil
+}
+
+// mergePairs两趟合并兄弟链表: 从左到右两两合并, 再从右到左依次合并
+func (heap *Heap) mergePairs(first *Handle) *Handle {
+	pairs := []*Handle{}
+	for first != nil {
+		a, b := first, first.sibling
+		if b == nil {
+			first = nil
+		} else {
+			first = b.sibling
+			b.sibling, b.prev = nil, nil
+		}
+		a.sibling, a.prev = nil, nil
+		pairs = append(pairs, heap.link(a, b))
+	}
+	var root *Handle
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = heap.link(pairs[i], root)
+	}
+	return root
+}
@@ -0,0 +1,351 @@
+package pairingheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func TestPairingHeapPushAndPop(t *testing.T) {
+	heap := NewWithIntComparator()
+
+	if actualValue, ok := heap.Pop(); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if actualValue, ok := heap.Peek(); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	heap.Push(3)
+	heap.Push(5, 1, 4)
+	handle := heap.PushHandle(2)
+	assertValidPairingHeap(t, heap)
+
+	if actualValue := heap.Size(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if actualValue, ok := heap.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	for _, expectedValue := range []int{1, 2, 3, 4, 5} {
+		if actualValue, ok := heap.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		assertValidPairingHeap(t, heap)
+	}
+	if actualValue := heap.Contains(handle); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestPairingHeapPushLinksUnderRoot(t *testing.T) {
+	heap := NewWithIntComparator()
+	heap.Push(1, 2, 3, 4, 5)
+
+	// Push只和根比较一次: 较大的值成为根的第一个孩子, 所以根的孩子是逆序的
+	expected := []int{5, 4, 3, 2}
+	actual := []int{}
+	for child := heap.root.child; child != nil; child = child.sibling {
+		actual = append(actual, child.value.(int))
+	}
+	if !equalInts(actual, expected) {
+		t.Errorf("Got %v expected %v", actual, expected)
+	}
+
+	// 两趟合并: 从左到右得到(4 5)和(2 3), 再从右到左把4链接到2下面
+	heap.Pop()
+	if actualValue := heap.root.value; actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue := heap.root.child.value; actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+	if actualValue := heap.root.child.child.value; actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if actualValue := heap.root.child.sibling.value; actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	assertValidPairingHeap(t, heap)
+}
+
+func TestPairingHeapDecreaseKeyAndRemove(t *testing.T) {
+	heap := NewWithIntComparator()
+	handles := []*Handle{}
+	for _, value := range []int{10, 20, 30, 40, 50} {
+		handles = append(handles, heap.PushHandle(value))
+	}
+	heap.Pop()
+	assertValidPairingHeap(t, heap)
+
+	// 非根节点减小后被剪下并与根链接, 不大于根时成为新的根
+	if actualValue := heap.DecreaseKey(handles[4], 25); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.root.child; actualValue != handles[4] {
+		t.Errorf("Got %v expected %v", actualValue.value, handles[4].value)
+	}
+	if actualValue := heap.DecreaseKey(handles[3], 5); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if top, ok := heap.PeekHandle(); top != handles[3] || !ok {
+		t.Errorf("Got %v expected %v", top.value, handles[3].value)
+	}
+	assertValidPairingHeap(t, heap)
+
+	if actualValue := heap.DecreaseKey(handles[0], 1); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	if actualValue := heap.Remove(handles[2]); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.Remove(handles[2]); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	assertValidPairingHeap(t, heap)
+	for _, expectedValue := range []int{5, 20, 25} {
+		if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+	handle := heap.PushHandle(1)
+	heap.Clear()
+	if actualValue := heap.Contains(handle); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+}
+
+func TestPairingHeapDecreaseKeyInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got %v expected %v", r, "panic")
+		}
+	}()
+	heap := NewWithIntComparator()
+	heap.DecreaseKey(heap.PushHandle(1), 2)
+}
+
+func TestPairingHeapMeld(t *testing.T) {
+	heap := NewWithIntComparator()
+	other := NewWithIntComparator()
+	heap.Push(4, 8)
+	handle := other.PushHandle(6)
+	other.Push(2, 9)
+	root, otherRoot := heap.root, other.root
+
+	// Meld只链接两个根, 两个堆原来的孩子链表保持不变
+	heap.Meld(other)
+	if actualValue := heap.root; actualValue != otherRoot {
+		t.Errorf("Got %v expected %v", actualValue.value, otherRoot.value)
+	}
+	if actualValue := heap.root.child; actualValue != root {
+		t.Errorf("Got %v expected %v", actualValue.value, root.value)
+	}
+	if actualValue := root.child.value; actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	if actualValue := root.sibling.value; actualValue != 9 {
+		t.Errorf("Got %v expected %v", actualValue, 9)
+	}
+	if actualValue := heap.Size(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if actualValue := other.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := other.Contains(handle); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	assertValidPairingHeap(t, heap)
+
+	heap.Meld(heap)
+	third := NewWithIntComparator()
+	third.Meld(heap)
+	if actualValue := third.Contains(handle); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	third.DecreaseKey(handle, 1)
+	for _, expectedValue := range []int{1, 2, 4, 8, 9} {
+		if actualValue, _ := third.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+func TestPairingHeapZeroValue(t *testing.T) {
+	heap := &Heap{Comparator: util.IntComparator}
+	if actualValue := heap.Contains(&Handle{}); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+	handle := heap.PushHandle(3)
+	heap.Push(1, 2)
+	if actualValue := heap.Contains(handle); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := heap.DecreaseKey(handle, 0); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	other := &Heap{Comparator: util.IntComparator}
+	other.Meld(heap)
+	heap.Meld(&Heap{Comparator: util.IntComparator})
+	if actualValue := other.Contains(handle); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	for _, expectedValue := range []int{0, 1, 2} {
+		if actualValue, ok := other.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if actualValue := other.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestPairingHeapRandom(t *testing.T) {
+	heaps := []*Heap{NewWithIntComparator(), NewWithIntComparator()}
+	r := rand.New(rand.NewSource(3))
+	handles := []*Handle{}
+	for i := 0; i < 2000; i++ {
+		handles = append(handles, heaps[i%2].PushHandle(r.Intn(1000)))
+	}
+	heap := heaps[0]
+	heap.Meld(heaps[1])
+	for i := 0; i < 1500; i++ {
+		if i%100 == 0 {
+			assertValidPairingHeap(t, heap)
+		}
+		handle := handles[r.Intn(len(handles))]
+		switch r.Intn(3) {
+		case 0:
+			if heap.Contains(handle) {
+				heap.DecreaseKey(handle, handle.Value().(int)-r.Intn(100))
+			}
+		case 1:
+			heap.Remove(handle)
+		default:
+			heap.Pop()
+		}
+	}
+	assertValidPairingHeap(t, heap)
+	expected := []int{}
+	for _, handle := range handles {
+		if heap.Contains(handle) {
+			expected = append(expected, handle.Value().(int))
+		}
+	}
+	sort.Ints(expected)
+	if actualValue, expectedValue := heap.Size(), len(expected); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for _, expectedValue := range expected {
+		if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			break
+		}
+	}
+}
+
+// assertValidPairingHeap检查堆序, prev指针(第一个孩子指向父亲, 其余指向上一个兄弟)以及大小
+func assertValidPairingHeap(t *testing.T, heap *Heap) {
+	if heap.root == nil {
+		if heap.Size() != 0 {
+			t.Errorf("Got size %v expected %v", heap.Size(), 0)
+		}
+		return
+	}
+	if heap.root.prev != nil || heap.root.sibling != nil {
+		t.Errorf("Got root %v with prev or sibling", heap.root.value)
+	}
+	var check func(handle *Handle) int
+	check = func(handle *Handle) int {
+		if !heap.Contains(handle) {
+			t.Errorf("Got handle %v not contained in the heap", handle.value)
+		}
+		size := 1
+		prev := handle
+		for child := handle.child; child != nil; child = child.sibling {
+			if child.prev != prev {
+				t.Errorf("Got prev %v expected %v", child.prev.value, prev.value)
+			}
+			if heap.Comparator(child.value, handle.value) < 0 {
+				t.Errorf("Got child %v less than %v", child.value, handle.value)
+			}
+			size += check(child)
+			prev = child
+		}
+		return size
+	}
+	if size := check(heap.root); size != heap.Size() {
+		t.Errorf("Got size %v expected %v", size, heap.Size())
+	}
+}
+
+func equalInts(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func benchmarkPush(b *testing.B, heap *Heap, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			heap.Push(n)
+		}
+	}
+}
+
+func benchmarkPop(b *testing.B, heap *Heap, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			heap.Pop()
+		}
+	}
+}
+
+func BenchmarkPairingHeapPush1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	heap := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkPairingHeapPush100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	heap := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPush(b, heap, size)
+}
+
+func BenchmarkPairingHeapPop1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	heap := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPop(b, heap, size)
+}
+
+func BenchmarkPairingHeapPop100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	heap := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		heap.Push(n)
+	}
+	b.StartTimer()
+	benchmarkPop(b, heap, size)
+}
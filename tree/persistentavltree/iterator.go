@@ -0,0 +1,113 @@
+package persistentavltree
+
+import "github.com/morganxf/algorithm/container"
+
+func assertIteratorImplementation() {
+	var _ container.ReverseIteratorWithKey = (*Iterator)(nil)
+}
+
+// Iterator holds the path from the root to the current node, since nodes shared between versions have no parent.
+type Iterator struct {
+	tree     *Tree
+	path     []*Node
+	position position
+}
+
+type position byte
+
+const (
+	begin, between, end = 0, 1, 2
+)
+
+func (t *Tree) Iterator() *Iterator {
+	return &Iterator{tree: t, position: begin}
+}
+
+func (it *Iterator) Next() bool {
+	switch it.position {
+	case begin:
+		it.bottom(0)
+	case between:
+		it.walk(1)
+	}
+	if len(it.path) == 0 {
+		it.End()
+		return false
+	}
+	it.position = between
+	return true
+}
+
+func (it *Iterator) Prev() bool {
+	switch it.position {
+	case end:
+		it.bottom(1)
+	case between:
+		it.walk(0)
+	}
+	if len(it.path) == 0 {
+		it.Begin()
+		return false
+	}
+	it.position = between
+	return true
+}
+
+func (it *Iterator) Key() interface{} {
+	if len(it.path) == 0 {
+		return nil
+	}
+	return it.path[len(it.path)-1].Key
+}
+
+func (it *Iterator) Value() interface{} {
+	if len(it.path) == 0 {
+		return nil
+	}
+	return it.path[len(it.path)-1].Value
+}
+
+func (it *Iterator) Begin() {
+	it.path = it.path[:0]
+	it.position = begin
+}
+
+func (it *Iterator) End() {
+	it.path = it.path[:0]
+	it.position = end
+}
+
+func (it *Iterator) First() bool {
+	it.Begin()
+	return it.Next()
+}
+
+func (it *Iterator) Last() bool {
+	it.End()
+	return it.Prev()
+}
+
+// bottom记录从根到最左(d==0)或最右(d==1)node的路径
+func (it *Iterator) bottom(d int) {
+	it.path = it.path[:0]
+	for cur := it.tree.root; cur != nil; cur = cur.children[d] {
+		it.path = append(it.path, cur)
+	}
+}
+
+// walk与avltree.Node.walk相同，只是用path代替Parent
+// d==1 寻找下一个node, d==0 寻找上一个node
+func (it *Iterator) walk(d int) {
+	cur := it.path[len(it.path)-1]
+	if child := cur.children[d]; child != nil {
+		for ; child != nil; child = child.children[d^1] {
+			it.path = append(it.path, child)
+		}
+		return
+	}
+	// 向上找到第一个从d^1方向到达的祖先
+	for len(it.path) > 1 && it.path[len(it.path)-2].children[d] == it.path[len(it.path)-1] {
+		it.path = it.path[:len(it.path)-1]
+	}
+	it.path = it.path[:len(it.path)-1]
+}
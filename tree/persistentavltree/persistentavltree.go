@@ -0,0 +1,339 @@
+// Package persistentavltree implements an immutable AVL tree.
+//
+// Put and Remove never modify a tree, they return a new version that shares every unmodified node
+// with the old one (path copying), so keeping old versions around is cheap and taking a snapshot is O(1).
+package persistentavltree
+
+import (
+	"fmt"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+type Tree struct {
+	root       *Node
+	Comparator util.Comparator
+	KeyDecoder util.KeyDecoder // 可选, FromJSON用于还原自定义类型的key
+	size       int
+}
+
+// Node is shared between versions of a tree and must not be modified.
+type Node struct {
+	Key      interface{}
+	Value    interface{}
+	children [2]*Node // Left - Right
+	height   int8
+}
+
+func NewWith(comparator util.Comparator) *Tree {
+	return &Tree{Comparator: comparator}
+}
+
+func NewWithIntComparator() *Tree {
+	return &Tree{Comparator: util.IntComparator}
+}
+
+func NewWithStringComparator() *Tree {
+	return &Tree{Comparator: util.StringComparator}
+}
+
+// Put returns a new version of the tree with key set to value. t is not modified.
+func (t *Tree) Put(key interface{}, value interface{}) *Tree {
+	root, added := t.put(t.root, key, value)
+	size := t.size
+	if added {
+		size++
+	}
+	return t.with(root, size)
+}
+
+// Remove returns a new version of the tree without key. t is not modified.
+// If key is not in the tree, t itself is returned.
+func (t *Tree) Remove(key interface{}) *Tree {
+	root, removed := t.remove(t.root, key)
+	if !removed {
+		return t
+	}
+	return t.with(root, t.size-1)
+}
+
+// Snapshot returns a version of the tree that is unaffected by later calls to Clear or FromJSON on t, in O(1).
+func (t *Tree) Snapshot() *Tree {
+	snapshot := *t
+	return &snapshot
+}
+
+func (t *Tree) Get(key interface{}) (interface{}, bool) {
+	cur := t.root
+	for cur != nil {
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp == 0:
+			return cur.Value, true
+		case cmp < 0:
+			cur = cur.children[0]
+		case cmp > 0:
+			cur = cur.children[1]
+		}
+	}
+	return nil, false
+}
+
+func (t *Tree) Left() *Node {
+	return t.bottom(0)
+}
+
+func (t *Tree) Right() *Node {
+	return t.bottom(1)
+}
+
+func (t *Tree) Empty() bool {
+	return t.size == 0
+}
+
+func (t *Tree) Size() int {
+	return t.size
+}
+
+func (t *Tree) Keys() []interface{} {
+	it := t.Iterator()
+	keys := make([]interface{}, 0, t.size)
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+func (t *Tree) Values() []interface{} {
+	it := t.Iterator()
+	values := make([]interface{}, 0, t.size)
+	for it.Next() {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
+// Clear empties this handle of the tree. Other versions and snapshots are not affected.
+func (t *Tree) Clear() {
+	t.root = nil
+	t.size = 0
+}
+
+func (t *Tree) Floor(key interface{}) (*Node, bool) {
+	var floor *Node
+	cur := t.root
+	for cur != nil {
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp < 0:
+			cur = cur.children[0]
+		case cmp > 0:
+			floor = cur
+			cur = cur.children[1]
+		case cmp == 0:
+			return cur, true
+		}
+	}
+	return floor, floor != nil
+}
+
+func (t *Tree) Ceiling(key interface{}) (*Node, bool) {
+	var ceiling *Node
+	cur := t.root
+	for cur != nil {
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp < 0:
+			ceiling = cur
+			cur = cur.children[0]
+		case cmp > 0:
+			cur = cur.children[1]
+		case cmp == 0:
+			return cur, true
+		}
+	}
+	return ceiling, ceiling != nil
+}
+
+func (t *Tree) String() string {
+	str := "PersistentAVLTree\n"
+	if !t.Empty() {
+		output(t.root, "", true, &str)
+	}
+	return str
+}
+
+// with返回一个与t有相同配置的新版本
+func (t *Tree) with(root *Node, size int) *Tree {
+	return &Tree{root: root, Comparator: t.Comparator, KeyDecoder: t.KeyDecoder, size: size}
+}
+
+func (t *Tree) bottom(d int) *Node {
+	if t.root == nil {
+		return nil
+	}
+	cur := t.root
+	for ; cur.children[d] != nil; cur = cur.children[d] {
+	}
+	return cur
+}
+
+// put返回新的子树根，只复制从根到key所在位置路径上的node
+// true: 新增了node
+func (t *Tree) put(n *Node, key interface{}, value interface{}) (*Node, bool) {
+	if n == nil {
+		return &Node{Key: key, Value: value, height: 1}, true
+	}
+	cmp := t.Comparator(key, n.Key)
+	cur := n.clone()
+	if cmp == 0 {
+		cur.Key = key
+		cur.Value = value
+		return cur, false
+	}
+	d := 0
+	if cmp > 0 {
+		d = 1
+	}
+	var added bool
+	cur.children[d], added = t.put(n.children[d], key, value)
+	return rebalance(cur), added
+}
+
+// remove返回新的子树根
+// true: 找到并删除了key
+func (t *Tree) remove(n *Node, key interface{}) (*Node, bool) {
+	if n == nil {
+		return nil, false
+	}
+	cmp := t.Comparator(key, n.Key)
+	if cmp == 0 {
+		if n.children[0] == nil {
+			return n.children[1], true
+		}
+		if n.children[1] == nil {
+			return n.children[0], true
+		}
+		// 使用右子树的最小node替换当前node
+		right, min := removeMin(n.children[1])
+		cur := min.clone()
+		cur.children = [2]*Node{n.children[0], right}
+		return rebalance(cur), true
+	}
+	d := 0
+	if cmp > 0 {
+		d = 1
+	}
+	child, removed := t.remove(n.children[d], key)
+	if !removed {
+		return n, false
+	}
+	cur := n.clone()
+	cur.children[d] = child
+	return rebalance(cur), true
+}
+
+// removeMin返回删除最小node后的新子树根和被删除的node
+func removeMin(n *Node) (*Node, *Node) {
+	if n.children[0] == nil {
+		return n.children[1], n
+	}
+	left, min := removeMin(n.children[0])
+	cur := n.clone()
+	cur.children[0] = left
+	return rebalance(cur), min
+}
+
+// rebalance调整新复制的node n，返回平衡后的子树根
+func rebalance(n *Node) *Node {
+	n.update()
+	switch n.balance() {
+	case 2:
+		if n.children[1].balance() < 0 {
+			n.children[1] = rotate(0, n.children[1].clone())
+		}
+		return rotate(1, n)
+	case -2:
+		if n.children[0].balance() > 0 {
+			n.children[0] = rotate(1, n.children[0].clone())
+		}
+		return rotate(0, n)
+	}
+	return n
+}
+
+// 旋转新复制的node n，将孩子d提升为新的根。被提升的孩子可能被其他版本共享，所以需要复制
+func rotate(d int, n *Node) *Node {
+	newRoot := n.children[d].clone()
+	n.children[d] = newRoot.children[d^1]
+	n.update()
+	newRoot.children[d^1] = n
+	newRoot.update()
+	return newRoot
+}
+
+func (n *Node) clone() *Node {
+	c := *n
+	return &c
+}
+
+func (n *Node) update() {
+	left, right := n.children[0].getHeight(), n.children[1].getHeight()
+	if left > right {
+		n.height = left + 1
+	} else {
+		n.height = right + 1
+	}
+}
+
+func (n *Node) getHeight() int8 {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *Node) balance() int8 {
+	return n.children[1].getHeight() - n.children[0].getHeight()
+}
+
+func (n *Node) Left() *Node {
+	return n.children[0]
+}
+
+func (n *Node) Right() *Node {
+	return n.children[1]
+}
+
+func (n *Node) String() string {
+	return fmt.Sprintf("%v", n.Key)
+}
+
+// 格式化的后置遍历
+func output(n *Node, prefix string, isTail bool, str *string) {
+	if n.children[1] != nil {
+		newPrefix := prefix
+		if isTail {
+			newPrefix += "│   "
+		} else {
+			newPrefix += "    "
+		}
+		output(n.children[1], newPrefix, false, str)
+	}
+	*str += prefix
+	if isTail {
+		*str += "└── "
+	} else {
+		*str += "┌── "
+	}
+	*str += n.String() + "\n"
+	if n.children[0] != nil {
+		newPrefix := prefix
+		if isTail {
+			newPrefix += "    "
+		} else {
+			newPrefix += "│   "
+		}
+		output(n.children[0], newPrefix, true, str)
+	}
+}
@@ -0,0 +1,312 @@
+package persistentavltree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestPersistentAVLTreePut(t *testing.T) {
+	tree := NewWithIntComparator()
+	tree = tree.Put(5, "e")
+	tree = tree.Put(6, "f")
+	tree = tree.Put(7, "g")
+	tree = tree.Put(3, "c")
+	tree = tree.Put(4, "d")
+	tree = tree.Put(1, "x")
+	tree = tree.Put(2, "b")
+	tree = tree.Put(1, "a") //overwrite
+
+	if actualValue := tree.Size(); actualValue != 7 {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%d%d%d%d%d%d%d", tree.Keys()...), "1234567"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%s%s%s%s%s%s%s", tree.Values()...), "abcdefg"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tests := [][]interface{}{
+		{1, "a", true},
+		{2, "b", true},
+		{3, "c", true},
+		{4, "d", true},
+		{5, "e", true},
+		{6, "f", true},
+		{7, "g", true},
+		{8, nil, false},
+	}
+
+	for _, test := range tests {
+		actualValue, actualFound := tree.Get(test[0])
+		if actualValue != test[1] || actualFound != test[2] {
+			t.Errorf("Got %v expected %v", actualValue, test[1])
+		}
+	}
+}
+
+func TestPersistentAVLTreeVersions(t *testing.T) {
+	v0 := NewWithIntComparator()
+	v1 := v0.Put(1, "a")
+	v2 := v1.Put(2, "b")
+	v3 := v2.Put(1, "x")
+	v4 := v3.Remove(2)
+	v5 := v4.Remove(8)
+
+	tests := [][]interface{}{
+		{v0, "[]", "[]"},
+		{v1, "[1]", "[a]"},
+		{v2, "[1 2]", "[a b]"},
+		{v3, "[1 2]", "[x b]"},
+		{v4, "[1]", "[x]"},
+		{v5, "[1]", "[x]"},
+	}
+	for _, test := range tests {
+		tree := test[0].(*Tree)
+		if actualValue, expectedValue := fmt.Sprint(tree.Keys()), test[1]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue, expectedValue := fmt.Sprint(tree.Values()), test[2]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if v5 != v4 {
+		t.Errorf("Removing a missing key should return the same version")
+	}
+
+	snapshot := v2.Snapshot()
+	v2.Clear()
+	if actualValue, expectedValue := fmt.Sprint(snapshot.Keys()), "[1 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := v2.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+}
+
+func TestPersistentAVLTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	tree := NewWithIntComparator()
+	versions := []*Tree{}
+	expected := []map[int]bool{}
+	present := map[int]bool{}
+	for i := 0; i < 1000; i++ {
+		key := r.Intn(200)
+		if r.Intn(3) == 0 {
+			tree = tree.Remove(key)
+			delete(present, key)
+		} else {
+			tree = tree.Put(key, key)
+			present[key] = true
+		}
+		if i%50 == 0 {
+			copied := map[int]bool{}
+			for k := range present {
+				copied[k] = true
+			}
+			versions = append(versions, tree)
+			expected = append(expected, copied)
+		}
+	}
+	for i, version := range versions {
+		assertValidTree(t, version)
+		if actualValue, expectedValue := version.Size(), len(expected[i]); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		for key := range expected[i] {
+			if _, found := version.Get(key); !found {
+				t.Errorf("Got %v expected %v for key %v", found, true, key)
+			}
+		}
+	}
+}
+
+func TestPersistentAVLTreeCeilingAndFloor(t *testing.T) {
+	tree := NewWithIntComparator()
+
+	if node, found := tree.Floor(0); node != nil || found {
+		t.Errorf("Got %v expected %v", node, "<nil>")
+	}
+	if node, found := tree.Ceiling(0); node != nil || found {
+		t.Errorf("Got %v expected %v", node, "<nil>")
+	}
+
+	for _, key := range []int{5, 6, 7, 3, 1, 2} {
+		tree = tree.Put(key, key)
+	}
+
+	if node, found := tree.Floor(4); node.Key != 3 || !found {
+		t.Errorf("Got %v expected %v", node.Key, 3)
+	}
+	if node, found := tree.Floor(0); node != nil || found {
+		t.Errorf("Got %v expected %v", node, "<nil>")
+	}
+	if node, found := tree.Ceiling(4); node.Key != 5 || !found {
+		t.Errorf("Got %v expected %v", node.Key, 5)
+	}
+	if node, found := tree.Ceiling(8); node != nil || found {
+		t.Errorf("Got %v expected %v", node, "<nil>")
+	}
+	if actualValue, expectedValue := tree.Left().Key, 1; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Right().Key, 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestPersistentAVLTreeIterator(t *testing.T) {
+	tree := NewWithIntComparator()
+	it := tree.Iterator()
+	for it.Next() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+	for it.Prev() {
+		t.Errorf("Shouldn't iterate on empty tree")
+	}
+
+	for _, key := range []int{13, 8, 17, 1, 11, 15, 25, 6, 22, 27} {
+		tree = tree.Put(key, key)
+	}
+	it = tree.Iterator()
+	keys := []interface{}{}
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[1 6 8 11 13 15 17 22 25 27]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	keys = keys[:0]
+	for it.Prev() {
+		keys = append(keys, it.Key())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[27 25 22 17 15 13 11 8 6 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue, expectedValue := it.First(), true; actualValue != expectedValue || it.Key() != 1 {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), expectedValue, 1)
+	}
+	if actualValue, expectedValue := it.Last(), true; actualValue != expectedValue || it.Key() != 27 {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, it.Key(), expectedValue, 27)
+	}
+	it.Prev()
+	it.Next()
+	if actualValue, expectedValue := it.Key(), 27; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	it.End()
+	if it.Key() != nil {
+		t.Errorf("Got %v expected %v", it.Key(), nil)
+	}
+}
+
+func TestPersistentAVLTreeSerialization(t *testing.T) {
+	tree := NewWithStringComparator()
+	tree = tree.Put("c", "3")
+	tree = tree.Put("b", "2")
+	tree = tree.Put("a", "1")
+
+	var err error
+	assert := func() {
+		if actualValue, expectedValue := tree.Size(), 3; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		if actualValue := tree.Keys(); actualValue[0].(string) != "a" || actualValue[1].(string) != "b" || actualValue[2].(string) != "c" {
+			t.Errorf("Got %v expected %v", actualValue, "[a,b,c]")
+		}
+		if actualValue := tree.Values(); actualValue[0].(string) != "1" || actualValue[1].(string) != "2" || actualValue[2].(string) != "3" {
+			t.Errorf("Got %v expected %v", actualValue, "[1,2,3]")
+		}
+		if err != nil {
+			t.Errorf("Got error %v", err)
+		}
+	}
+
+	assert()
+
+	json, err := tree.ToJSON()
+	assert()
+
+	err = tree.FromJSON(json)
+	assert()
+}
+
+func TestPersistentAVLTreeSerializationKeyTypes(t *testing.T) {
+	tree := NewWithIntComparator()
+	tree = tree.Put(10, "a")
+	tree = tree.Put(2, "b")
+	snapshot := tree.Snapshot()
+
+	data, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if err = tree.FromJSON(data); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprintf("%#v", tree.Keys()), "[]interface {}{2, 10}"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err = tree.FromJSON([]byte("[]")); err != nil || !tree.Empty() {
+		t.Errorf("Got %v,%v expected %v,%v", err, tree.Empty(), nil, true)
+	}
+	if actualValue, expectedValue := snapshot.Size(), 2; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+// assertValidTree checks heights, balance and key order.
+func assertValidTree(t *testing.T, tree *Tree) {
+	var check func(n *Node) int8
+	check = func(n *Node) int8 {
+		if n == nil {
+			return 0
+		}
+		left, right := check(n.children[0]), check(n.children[1])
+		if right-left > 1 || left-right > 1 {
+			t.Errorf("Node %v is not balanced", n)
+		}
+		height := left + 1
+		if right > left {
+			height = right + 1
+		}
+		if n.height != height {
+			t.Errorf("Got height %v expected %v for node %v", n.height, height, n)
+		}
+		return height
+	}
+	check(tree.root)
+	keys := tree.Keys()
+	for i := 1; i < len(keys); i++ {
+		if tree.Comparator(keys[i-1], keys[i]) >= 0 {
+			t.Errorf("Keys out of order: %v", keys)
+			break
+		}
+	}
+}
+
+func benchmarkPut(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree = tree.Put(n, struct{}{})
+		}
+	}
+}
+
+func BenchmarkPersistentAVLTreePut1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPut(b, tree, size)
+}
+
+func BenchmarkPersistentAVLTreePut100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPut(b, tree, size)
+}
@@ -0,0 +1,28 @@
+package persistentavltree
+
+import "github.com/morganxf/algorithm/util"
+
+// ToJSON encodes the tree as a JSON array of {key,value} pairs in key order.
+// The type of built-in keys is recorded so that FromJSON can restore it.
+func (t *Tree) ToJSON() ([]byte, error) {
+	return util.MarshalEntries(t.Keys(), t.Values())
+}
+
+// FromJSON replaces the contents of this handle of the tree with the output of ToJSON.
+// Keys of custom types are restored by t.KeyDecoder. Other versions and snapshots are not affected.
+// The tree must be created by a constructor, a zero value Tree returns util.ErrNotConstructed.
+func (t *Tree) FromJSON(data []byte) error {
+	if t.Comparator == nil {
+		return util.ErrNotConstructed
+	}
+	keys, values, err := util.UnmarshalEntries(data, t.KeyDecoder)
+	if err != nil {
+		return err
+	}
+	tree := t.with(nil, 0)
+	for i, key := range keys {
+		tree = tree.Put(key, values[i])
+	}
+	*t = *tree
+	return nil
+}
@@ -0,0 +1,95 @@
+package radixtree
+
+import "github.com/morganxf/algorithm/container"
+
+func assertIteratorImplementation() {
+	var _ container.ReverseIteratorWithKey = (*Iterator)(nil)
+}
+
+// Iterator is a stateful iterator over the entries in lexicographic key order. Key returns a string.
+type Iterator struct {
+	tree     *Tree
+	node     *node
+	position position
+}
+
+type position byte
+
+const (
+	begin, between, end = 0, 1, 2
+)
+
+func (t *Tree) Iterator() *Iterator {
+	return &Iterator{tree: t, node: nil, position: begin}
+}
+
+func (it *Iterator) Next() bool {
+	switch it.position {
+	case begin:
+		it.node = &it.tree.root
+		it.position = between
+	case between:
+		it.node = it.node.next()
+	}
+	// 跳过没有entry的中间node
+	for it.node != nil && !it.node.leaf {
+		it.node = it.node.next()
+	}
+	if it.node == nil {
+		it.position = end
+		return false
+	}
+	return true
+}
+
+func (it *Iterator) Prev() bool {
+	switch it.position {
+	case end:
+		it.node = it.tree.root.last()
+		it.position = between
+	case between:
+		it.node = it.node.prev()
+	}
+	for it.node != nil && !it.node.leaf {
+		it.node = it.node.prev()
+	}
+	if it.node == nil {
+		it.position = begin
+		return false
+	}
+	return true
+}
+
+func (it *Iterator) Key() interface{} {
+	if it.node == nil {
+		return nil
+	}
+	return it.node.key
+}
+
+func (it *Iterator) Value() interface{} {
+	if it.node == nil {
+		return nil
+	}
+	return it.node.value
+}
+
+func (it *Iterator) Begin() {
+	it.node = nil
+	it.position = begin
+}
+
+func (it *Iterator) End() {
+	it.node = nil
+	it.position = end
+}
+
+func (it *Iterator) First() bool {
+	it.Begin()
+	return it.Next()
+}
+
+func (it *Iterator) Last() bool {
+	it.End()
+	return it.Prev()
+}
@@ -0,0 +1,267 @@
+package radixtree
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// Tree is a compressed radix tree keyed by strings. Nodes with a single child and no value are merged
+// into their child, so the height is bounded by the length of the longest key and not by the number of keys.
+// Keys are ordered byte-wise, as by the < operator on strings. The zero value is an empty tree.
+type Tree struct {
+	root node // 以值存放, 零值Tree就是一棵空树
+	size int
+}
+
+type node struct {
+	key      string // 从根到该node的完整key, 边上的label为key[len(parent.key):]
+	leaf     bool   // 是否存放了一个entry
+	value    interface{}
+	parent   *node
+	children []*node // 按照label的首字节排序
+}
+
+func New() *Tree {
+	return &Tree{}
+}
+
+func (t *Tree) Put(key string, value interface{}) {
+	n := &t.root
+	for {
+		if len(n.key) == len(key) {
+			if !n.leaf {
+				n.leaf = true
+				t.size++
+			}
+			n.value = value
+			return
+		}
+		index, child := n.child(key[len(n.key)])
+		if child == nil {
+			n.insertChild(index, &node{key: key, leaf: true, value: value})
+			t.size++
+			return
+		}
+		common := len(n.key) + commonPrefixLength(key[len(n.key):], child.key[len(n.key):])
+		if common == len(child.key) {
+			n = child
+			continue
+		}
+		// 在公共前缀处分裂边: n -> mid -> child
+		mid := &node{key: key[:common], parent: n, children: []*node{child}}
+		n.children[index] = mid
+		child.parent = mid
+		n = mid
+	}
+}
+
+func (t *Tree) Get(key string) (interface{}, bool) {
+	if n := t.lookup(key); n != nil {
+		return n.value, true
+	}
+	return nil, false
+}
+
+func (t *Tree) Remove(key string) {
+	n := t.lookup(key)
+	if n == nil {
+		return
+	}
+	n.leaf, n.value = false, nil
+	t.size--
+	// 删除没有孩子的node, 再把只有一个孩子的非entry node合并到孩子中
+	if n != &t.root && len(n.children) == 0 {
+		parent := n.parent
+		parent.removeChild(n)
+		n = parent
+	}
+	if n != &t.root && !n.leaf && len(n.children) == 1 {
+		child := n.children[0]
+		index, _ := n.parent.child(child.key[len(n.parent.key)])
+		n.parent.children[index] = child
+		child.parent = n.parent
+	}
+}
+
+// LongestPrefixMatch returns the longest key in the tree that is a prefix of the given key, and its value.
+func (t *Tree) LongestPrefixMatch(key string) (string, interface{}, bool) {
+	var match *node
+	n := &t.root
+	for n != nil && strings.HasPrefix(key, n.key) {
+		if n.leaf {
+			match = n
+		}
+		if len(n.key) == len(key) {
+			break
+		}
+		_, n = n.child(key[len(n.key)])
+	}
+	if match == nil {
+		return "", nil, false
+	}
+	return match.key, match.value, true
+}
+
+// WalkPrefix calls f for every key starting with prefix in lexicographic order, until f returns false.
+func (t *Tree) WalkPrefix(prefix string, f func(key string, value interface{}) bool) {
+	n := &t.root
+	for len(n.key) < len(prefix) {
+		_, n = n.child(prefix[len(n.key)])
+		if n == nil {
+			return
+		}
+		// label可能比prefix长, 只比较重叠的部分
+		if length := min(len(n.key), len(prefix)); n.key[:length] != prefix[:length] {
+			return
+		}
+	}
+	n.walk(f)
+}
+
+func (t *Tree) Empty() bool {
+	return t.size == 0
+}
+
+func (t *Tree) Size() int {
+	return t.size
+}
+
+// Keys returns all the keys in lexicographic order.
+func (t *Tree) Keys() []string {
+	keys := make([]string, 0, t.size)
+	t.root.walk(func(key string, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Values returns all the values in the lexicographic order of their keys.
+func (t *Tree) Values() []interface{} {
+	values := make([]interface{}, 0, t.size)
+	t.root.walk(func(key string, value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+func (t *Tree) Clear() {
+	t.root = node{}
+	t.size = 0
+}
+
+// String outputs one edge label per line, indented by depth. Labels of nodes holding an entry end with '*'.
+func (t *Tree) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("RadixTree\n")
+	if t.root.leaf {
+		buffer.WriteString("*\n")
+	}
+	for _, child := range t.root.children {
+		child.output(&buffer, 0)
+	}
+	return buffer.String()
+}
+
+func (t *Tree) lookup(key string) *node {
+	n := &t.root
+	for n != nil && len(n.key) < len(key) {
+		_, n = n.child(key[len(n.key)])
+	}
+	if n == nil || n.key != key || !n.leaf {
+		return nil
+	}
+	return n
+}
+
+// child返回label以c开头的孩子及其index; 不存在时返回nil以及插入的位置
+func (n *node) child(c byte) (int, *node) {
+	depth := len(n.key)
+	index := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].key[depth] >= c
+	})
+	if index < len(n.children) && n.children[index].key[depth] == c {
+		return index, n.children[index]
+	}
+	return index, nil
+}
+
+func (n *node) insertChild(index int, child *node) {
+	child.parent = n
+	n.children = append(n.children, nil)
+	copy(n.children[index+1:], n.children[index:])
+	n.children[index] = child
+}
+
+func (n *node) removeChild(child *node) {
+	index, _ := n.child(child.key[len(n.key)])
+	n.children = append(n.children[:index], n.children[index+1:]...)
+}
+
+// walk前序遍历子树, 即按照key的字典序; f返回false时停止并返回false
+func (n *node) walk(f func(key string, value interface{}) bool) bool {
+	if n.leaf && !f(n.key, n.value) {
+		return false
+	}
+	for _, child := range n.children {
+		if !child.walk(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// next返回前序遍历中的下一个node
+func (n *node) next() *node {
+	if len(n.children) > 0 {
+		return n.children[0]
+	}
+	for ; n.parent != nil; n = n.parent {
+		index, _ := n.parent.child(n.key[len(n.parent.key)])
+		if index+1 < len(n.parent.children) {
+			return n.parent.children[index+1]
+		}
+	}
+	return nil
+}
+
+// prev返回前序遍历中的上一个node: 前一个兄弟子树中最后遍历的node, 或者parent
+func (n *node) prev() *node {
+	if n.parent == nil {
+		return nil
+	}
+	index, _ := n.parent.child(n.key[len(n.parent.key)])
+	if index == 0 {
+		return n.parent
+	}
+	return n.parent.children[index-1].last()
+}
+
+// last返回前序遍历子树时最后的node
+func (n *node) last() *node {
+	for len(n.children) > 0 {
+		n = n.children[len(n.children)-1]
+	}
+	return n
+}
+
+func (n *node) output(buffer *bytes.Buffer, level int) {
+	buffer.WriteString(strings.Repeat("    ", level))
+	buffer.WriteString(n.key[len(n.parent.key):])
+	if n.leaf {
+		buffer.WriteString("*")
+	}
+	buffer.WriteString("\n")
+	for _, child := range n.children {
+		child.output(buffer, level+1)
+	}
+}
+
+func commonPrefixLength(a string, b string) int {
+	i := 0
+	for ; i < len(a) && i < len(b) && a[i] == b[i]; i++ {
+	}
+	return i
+}
@@ -0,0 +1,339 @@
+package radixtree
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestRadixTreePutGetRemove(t *testing.T) {
+	tree := New()
+	tree.Put("romane", 1)
+	tree.Put("romanus", 2)
+	tree.Put("romulus", 3)
+	tree.Put("rubens", 4)
+	tree.Put("ruber", 5)
+	tree.Put("rubicon", 6)
+	tree.Put("rubicundus", 7)
+	tree.Put("", 0)
+	tree.Put("romane", 10) //overwrite
+
+	if actualValue := tree.Size(); actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[ romane romanus romulus rubens ruber rubicon rubicundus]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Values()), "[0 10 2 3 4 5 6 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	tests := [][]interface{}{
+		{"", 0, true},
+		{"romane", 10, true},
+		{"rubicundus", 7, true},
+		{"rom", nil, false},
+		{"roman", nil, false},
+		{"romanes", nil, false},
+		{"x", nil, false},
+	}
+	for _, test := range tests {
+		if value, found := tree.Get(test[0].(string)); value != test[1] || found != test[2] {
+			t.Errorf("Got %v,%v expected %v,%v", value, found, test[1], test[2])
+		}
+	}
+
+	expected := `RadixTree
+*
+r
+    om
+        an
+            e*
+            us*
+        ulus*
+    ub
+        e
+            ns*
+            r*
+        ic
+            on*
+            undus*
+`
+	if actualValue := tree.String(); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+
+	tree.Remove("romanus")
+	tree.Remove("rubens")
+	tree.Remove("rubicon")
+	tree.Remove("rom")
+	tree.Remove("")
+	expected = `RadixTree
+r
+    om
+        ane*
+        ulus*
+    ub
+        er*
+        icundus*
+`
+	if actualValue := tree.String(); actualValue != expected {
+		t.Errorf("Got %v expected %v", actualValue, expected)
+	}
+	if actualValue := tree.Size(); actualValue != 4 {
+		t.Errorf("Got %v expected %v", actualValue, 4)
+	}
+
+	for _, key := range tree.Keys() {
+		tree.Remove(key)
+	}
+	if actualValue, expectedValue := tree.String(), "RadixTree\n"; actualValue != expectedValue || !tree.Empty() {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestRadixTreeZeroValue(t *testing.T) {
+	tree := &Tree{}
+	if actualValue, found := tree.Get("ab"); actualValue != nil || found {
+		t.Errorf("Got %v,%v expected %v,%v", actualValue, found, nil, false)
+	}
+	if it := tree.Iterator(); it.Next() {
+		t.Errorf("Got %v expected %v", it.Key(), "end")
+	}
+	tree.Remove("ab")
+	tree.Put("ab", 1)
+	tree.Put("a", 2)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys(), tree.Values()), "[a ab] [2 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if key, value, found := tree.LongestPrefixMatch("abc"); key != "ab" || value != 1 || !found {
+		t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, "ab", 1, true)
+	}
+}
+
+func TestRadixTreeLongestPrefixMatch(t *testing.T) {
+	tree := New()
+	tree.Put("/", "root")
+	tree.Put("/api", "api")
+	tree.Put("/api/v1", "v1")
+	tree.Put("/apix", "apix")
+
+	tests := [][]interface{}{
+		{"/api/v1/users", "/api/v1", "v1", true},
+		{"/api/v2", "/api", "api", true},
+		{"/api", "/api", "api", true},
+		{"/ap", "/", "root", true},
+		{"/apixy", "/apix", "apix", true},
+		{"api", "", nil, false},
+		{"", "", nil, false},
+	}
+	for _, test := range tests {
+		if key, value, found := tree.LongestPrefixMatch(test[0].(string)); key != test[1] || value != test[2] || found != test[3] {
+			t.Errorf("Got %v,%v,%v expected %v,%v,%v", key, value, found, test[1], test[2], test[3])
+		}
+	}
+}
+
+func TestRadixTreeWalkPrefix(t *testing.T) {
+	tree := New()
+	for _, key := range []string{"team", "tea", "ten", "to", "toast", "i", "in", "inn"} {
+		tree.Put(key, len(key))
+	}
+
+	tests := []struct {
+		prefix   string
+		expected string
+	}{
+		{"", "[i in inn tea team ten to toast]"},
+		{"t", "[tea team ten to toast]"},
+		{"te", "[tea team ten]"},
+		{"tea", "[tea team]"},
+		{"teams", "[]"},
+		{"toa", "[toast]"},
+		{"tx", "[]"},
+		{"x", "[]"},
+	}
+	for _, test := range tests {
+		keys := []string{}
+		tree.WalkPrefix(test.prefix, func(key string, value interface{}) bool {
+			if value != len(key) {
+				t.Errorf("Got %v expected %v", value, len(key))
+			}
+			keys = append(keys, key)
+			return true
+		})
+		if actualValue := fmt.Sprint(keys); actualValue != test.expected {
+			t.Errorf("Got %v expected %v for %q", actualValue, test.expected, test.prefix)
+		}
+	}
+
+	count := 0
+	tree.WalkPrefix("t", func(key string, value interface{}) bool {
+		count++
+		return count < 2
+	})
+	if count != 2 {
+		t.Errorf("Got %v expected %v", count, 2)
+	}
+}
+
+func TestRadixTreeIterator(t *testing.T) {
+	tree := New()
+	it := tree.Iterator()
+	if it.Next() || it.Prev() {
+		t.Errorf("Got an element in an empty tree")
+	}
+
+	keys := []string{"b", "ab", "abc", "a", "", "abd", "c"}
+	for i, key := range keys {
+		tree.Put(key, i)
+	}
+	sort.Strings(keys)
+
+	it = tree.Iterator()
+	count := 0
+	for it.Next() {
+		if key := it.Key(); key != keys[count] {
+			t.Errorf("Got %v expected %v", key, keys[count])
+		}
+		count++
+	}
+	if count != len(keys) {
+		t.Errorf("Got %v expected %v", count, len(keys))
+	}
+	for it.Prev() {
+		count--
+		if key := it.Key(); key != keys[count] {
+			t.Errorf("Got %v expected %v", key, keys[count])
+		}
+	}
+	if count != 0 {
+		t.Errorf("Got %v expected %v", count, 0)
+	}
+	if !it.Last() || it.Key() != "c" || it.Value() != 6 {
+		t.Errorf("Got %v,%v expected %v,%v", it.Key(), it.Value(), "c", 6)
+	}
+	if !it.First() || it.Key() != "" || it.Value() != 4 {
+		t.Errorf("Got %v,%v expected %v,%v", it.Key(), it.Value(), "", 4)
+	}
+}
+
+func TestRadixTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	randomKey := func() string {
+		var key strings.Builder
+		for i := r.Intn(6); i > 0; i-- {
+			key.WriteByte("abc"[r.Intn(3)])
+		}
+		return key.String()
+	}
+	tree := New()
+	expected := make(map[string]int)
+	for i := 0; i < 3000; i++ {
+		key := randomKey()
+		if r.Intn(2) == 0 {
+			tree.Put(key, i)
+			expected[key] = i
+		} else {
+			tree.Remove(key)
+			delete(expected, key)
+		}
+	}
+	keys := make([]string, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), fmt.Sprint(keys); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for key, value := range expected {
+		if actualValue, found := tree.Get(key); actualValue != value || !found {
+			t.Errorf("Got %v,%v expected %v,%v", actualValue, found, value, true)
+		}
+	}
+	assertCompressed(t, &tree.root)
+}
+
+// assertCompressed检查除根以外没有entry的node至少有两个孩子
+func assertCompressed(t *testing.T, n *node) {
+	for _, child := range n.children {
+		if !child.leaf && len(child.children) < 2 {
+			t.Errorf("Got uncompressed node %q", child.key)
+		}
+		if child.parent != n || !strings.HasPrefix(child.key, n.key) || len(child.key) == len(n.key) {
+			t.Errorf("Got invalid child %q of %q", child.key, n.key)
+		}
+		assertCompressed(t, child)
+	}
+}
+
+func TestRadixTreeSerialization(t *testing.T) {
+	tree := New()
+	tree.Put("b", 2)
+	tree.Put("a", 1)
+	tree.Put("ab", 3)
+
+	data, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := string(data), `[{"type":"string","key":"a","value":1},{"type":"string","key":"ab","value":3},{"type":"string","key":"b","value":2}]`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	restored := New()
+	if err := restored.FromJSON(data); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(restored.Keys(), restored.Values()), "[a ab b] [1 3 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if err := restored.FromJSON([]byte(`[{"type":"int","key":1,"value":1}]`)); err != ErrNonStringKey {
+		t.Errorf("Got error %v expected %v", err, ErrNonStringKey)
+	}
+}
+
+func benchmarkGet(b *testing.B, tree *Tree, keys []string) {
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			tree.Get(key)
+		}
+	}
+}
+
+func benchmarkPut(b *testing.B, tree *Tree, keys []string) {
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			tree.Put(key, struct{}{})
+		}
+	}
+}
+
+func benchmarkKeys(size int) []string {
+	keys := make([]string, size)
+	for n := range keys {
+		keys[n] = fmt.Sprintf("/users/%d/profile", n)
+	}
+	return keys
+}
+
+func BenchmarkRadixTreeGet10000(b *testing.B) {
+	b.StopTimer()
+	keys := benchmarkKeys(10000)
+	tree := New()
+	for _, key := range keys {
+		tree.Put(key, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, tree, keys)
+}
+
+func BenchmarkRadixTreePut10000(b *testing.B) {
+	b.StopTimer()
+	keys := benchmarkKeys(10000)
+	tree := New()
+	b.StartTimer()
+	benchmarkPut(b, tree, keys)
+}
@@ -0,0 +1,60 @@
+package radixtree
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/util"
+)
+
+func assertSerializationImplementation() {
+	var _ container.JSONSerializer = (*Tree)(nil)
+	var _ container.JSONDeserializer = (*Tree)(nil)
+	var _ json.Marshaler = (*Tree)(nil)
+	var _ json.Unmarshaler = (*Tree)(nil)
+}
+
+// ErrNonStringKey is returned by FromJSON when an entry has a key that is not a string.
+var ErrNonStringKey = errors.New("radix tree key is not a string")
+
+// ToJSON encodes the tree as a JSON array of {key,value} pairs in key order,
+// in the same format as the other tree packages.
+func (t *Tree) ToJSON() ([]byte, error) {
+	keys := make([]interface{}, 0, t.size)
+	values := make([]interface{}, 0, t.size)
+	t.root.walk(func(key string, value interface{}) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return true
+	})
+	return util.MarshalEntries(keys, values)
+}
+
+// FromJSON replaces the contents of the tree with the output of ToJSON.
+func (t *Tree) FromJSON(data []byte) error {
+	keys, values, err := util.UnmarshalEntries(data, nil)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, ok := key.(string); !ok {
+			return ErrNonStringKey
+		}
+	}
+	t.Clear()
+	for i, key := range keys {
+		t.Put(key.(string), values[i])
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, it is equivalent to ToJSON.
+func (t *Tree) MarshalJSON() ([]byte, error) {
+	return t.ToJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it is equivalent to FromJSON.
+func (t *Tree) UnmarshalJSON(data []byte) error {
+	return t.FromJSON(data)
+}
@@ -0,0 +1,389 @@
+package redblacktree
+
+import (
+	"fmt"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+// Tree is a red-black tree. It has the same API as avltree.Tree, but needs at most
+// two rotations per Put and three per Remove.
+type Tree struct {
+	Root       *Node
+	Comparator util.Comparator
+	KeyDecoder util.KeyDecoder // 可选, FromJSON用于还原自定义类型的key
+	size       int
+}
+
+type color bool
+
+const (
+	black, red color = true, false
+)
+
+type Node struct {
+	Key      interface{}
+	Value    interface{}
+	Parent   *Node
+	Children [2]*Node // Left - Right
+	color    color
+}
+
+func NewWith(comparator util.Comparator) *Tree {
+	return &Tree{Comparator: comparator}
+}
+
+func NewWithIntComparator() *Tree {
+	return &Tree{Comparator: util.IntComparator}
+}
+
+func NewWithStringComparator() *Tree {
+	return &Tree{Comparator: util.StringComparator}
+}
+
+func (t *Tree) Put(key interface{}, value interface{}) {
+	if t.Root == nil {
+		t.Root = &Node{Key: key, Value: value, color: black}
+		t.size++
+		return
+	}
+	cur := t.Root
+	for {
+		cmp := t.Comparator(key, cur.Key)
+		if cmp == 0 {
+			cur.Key = key
+			cur.Value = value
+			return
+		}
+		d := 0
+		if cmp > 0 {
+			d = 1
+		}
+		if cur.Children[d] == nil {
+			node := &Node{Key: key, Value: value, Parent: cur, color: red}
+			cur.Children[d] = node
+			t.putFix(node)
+			t.size++
+			return
+		}
+		cur = cur.Children[d]
+	}
+}
+
+func (t *Tree) Get(key interface{}) (interface{}, bool) {
+	node := t.lookup(key)
+	if node == nil {
+		return nil, false
+	}
+	return node.Value, true
+}
+
+func (t *Tree) Remove(key interface{}) {
+	node := t.lookup(key)
+	if node == nil {
+		return
+	}
+	if node.Children[0] != nil && node.Children[1] != nil {
+		// 与avltree相同，用后继node的值替换当前node，转化为删除后继node
+		successor := node.Children[1]
+		for successor.Children[0] != nil {
+			successor = successor.Children[0]
+		}
+		node.Key, node.Value = successor.Key, successor.Value
+		node = successor
+	}
+	// node最多只有一个孩子
+	child := node.Children[0]
+	if child == nil {
+		child = node.Children[1]
+	}
+	if node.color == black {
+		if child != nil {
+			// 黑node只有一个孩子时，孩子一定是红色，染黑即可
+			child.color = black
+		} else {
+			// 删除黑色叶子会减少黑高，先以node本身为起点修复，再摘除node
+			t.removeFix(node)
+		}
+	}
+	t.replace(node, child)
+	t.size--
+}
+
+func (t *Tree) Left() *Node {
+	return t.bottom(0)
+}
+
+func (t *Tree) Right() *Node {
+	return t.bottom(1)
+}
+
+func (t *Tree) Empty() bool {
+	return t.size == 0
+}
+
+func (t *Tree) Size() int {
+	return t.size
+}
+
+func (t *Tree) Keys() []interface{} {
+	it := t.Iterator()
+	keys := make([]interface{}, 0, t.size)
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+func (t *Tree) Values() []interface{} {
+	it := t.Iterator()
+	values := make([]interface{}, 0, t.size)
+	for it.Next() {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
+func (t *Tree) Clear() {
+	t.Root = nil
+	t.size = 0
+}
+
+// Floor returns the node with the largest key less than or equal to the given key.
+func (t *Tree) Floor(key interface{}) (*Node, bool) {
+	var floor *Node
+	cur := t.Root
+	for cur != nil {
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp < 0:
+			cur = cur.Children[0]
+		case cmp > 0:
+			floor = cur
+			cur = cur.Children[1]
+		default:
+			return cur, true
+		}
+	}
+	return floor, floor != nil
+}
+
+// Ceiling returns the node with the smallest key greater than or equal to the given key.
+func (t *Tree) Ceiling(key interface{}) (*Node, bool) {
+	var ceiling *Node
+	cur := t.Root
+	for cur != nil {
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp < 0:
+			ceiling = cur
+			cur = cur.Children[0]
+		case cmp > 0:
+			cur = cur.Children[1]
+		default:
+			return cur, true
+		}
+	}
+	return ceiling, ceiling != nil
+}
+
+func (t *Tree) String() string {
+	str := "Tree\n"
+	if !t.Empty() {
+		output(t.Root, "", true, &str)
+	}
+	return str
+}
+
+func (t *Tree) lookup(key interface{}) *Node {
+	cur := t.Root
+	for cur != nil {
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp == 0:
+			return cur
+		case cmp < 0:
+			cur = cur.Children[0]
+		case cmp > 0:
+			cur = cur.Children[1]
+		}
+	}
+	return nil
+}
+
+func (t *Tree) bottom(d int) *Node {
+	if t.Root == nil {
+		return nil
+	}
+	var cur = t.Root
+	for ; cur.Children[d] != nil; cur = cur.Children[d] {
+	}
+	return cur
+}
+
+// putFix修复插入红色node后可能出现的连续红色node
+func (t *Tree) putFix(node *Node) {
+	for node.Parent != nil && node.Parent.color == red {
+		// parent为红色，一定不是根，grandparent存在
+		parent := node.Parent
+		grandparent := parent.Parent
+		d := parent.direction()
+		uncle := grandparent.Children[d^1]
+		if isRed(uncle) {
+			// 叔叔为红色: parent和uncle染黑，grandparent染红，继续向上修复
+			parent.color, uncle.color, grandparent.color = black, black, red
+			node = grandparent
+			continue
+		}
+		if node == parent.Children[d^1] {
+			// node在内侧，先旋转到外侧
+			t.rotate(d, parent)
+			node, parent = parent, node
+		}
+		// node在外侧，旋转grandparent，parent成为子树的根
+		parent.color, grandparent.color = black, red
+		t.rotate(d^1, grandparent)
+	}
+	t.Root.color = black
+}
+
+// removeFix修复黑色node被删除前缺少的一个黑高, node仍在树中
+func (t *Tree) removeFix(node *Node) {
+	for node != t.Root && node.color == black {
+		parent := node.Parent
+		d := node.direction()
+		// 黑高约束保证兄弟node存在
+		sibling := parent.Children[d^1]
+		if sibling.color == red {
+			// 兄弟为红色: 旋转parent，转化为兄弟为黑色的情况
+			sibling.color, parent.color = black, red
+			t.rotate(d, parent)
+			sibling = parent.Children[d^1]
+		}
+		if !isRed(sibling.Children[0]) && !isRed(sibling.Children[1]) {
+			// 兄弟的孩子都是黑色: 兄弟染红，缺少的黑高转移给parent
+			sibling.color = red
+			node = parent
+			continue
+		}
+		if !isRed(sibling.Children[d^1]) {
+			// 远侄子为黑色，近侄子为红色: 旋转兄弟，使远侄子为红色
+			sibling.Children[d].color, sibling.color = black, red
+			t.rotate(d^1, sibling)
+			sibling = parent.Children[d^1]
+		}
+		// 远侄子为红色: 旋转parent，补足黑高
+		sibling.color, parent.color = parent.color, black
+		sibling.Children[d^1].color = black
+		t.rotate(d, parent)
+		node = t.Root
+	}
+	node.color = black
+}
+
+// rotate把root的d^1孩子旋转为子树的根, d == 0为左旋, d == 1为右旋
+func (t *Tree) rotate(d int, root *Node) {
+	newRoot := root.Children[d^1]
+	root.Children[d^1] = newRoot.Children[d]
+	if newRoot.Children[d] != nil {
+		newRoot.Children[d].Parent = root
+	}
+	t.replace(root, newRoot)
+	newRoot.Children[d] = root
+	root.Parent = newRoot
+}
+
+// replace用new替换old在parent中的位置, new可以为nil
+func (t *Tree) replace(old *Node, new *Node) {
+	if old.Parent == nil {
+		t.Root = new
+	} else {
+		old.Parent.Children[old.direction()] = new
+	}
+	if new != nil {
+		new.Parent = old.Parent
+	}
+}
+
+func isRed(n *Node) bool {
+	return n != nil && n.color == red
+}
+
+func (n *Node) Left() *Node {
+	return n.Children[0]
+}
+
+func (n *Node) Right() *Node {
+	return n.Children[1]
+}
+
+func (n *Node) Next() *Node {
+	return n.walk(1)
+}
+
+func (n *Node) Prev() *Node {
+	return n.walk(0)
+}
+
+func (n *Node) String() string {
+	return fmt.Sprintf("%v", n.Key)
+}
+
+// direction返回n是parent的左孩子(0)还是右孩子(1)
+func (n *Node) direction() int {
+	if n.Parent.Children[0] == n {
+		return 0
+	}
+	return 1
+}
+
+// 如果d==1, 则代表寻找第一个比之大的节点。为当前节点右子孩子中最小的节点或者祖先节点的第一个比之大的右孩子
+// 如果d==0, 则代表寻找第一个比之小的节点。为当前节点左子孩子中最大的节点或者祖先节点的第一个比之小的左孩子
+func (n *Node) walk(d int) *Node {
+	if n == nil {
+		return nil
+	}
+	cur := n
+	if cur.Children[d] == nil {
+		parent := cur.Parent
+		for parent != nil && parent.Children[d] == cur {
+			cur = parent
+			parent = parent.Parent
+		}
+		return parent
+	}
+	child := cur.Children[d]
+	for child.Children[d^1] != nil {
+		child = child.Children[d^1]
+	}
+	return child
+}
+
+// 格式化的后置遍历, 与avltree的输出格式相同
+func output(n *Node, prefix string, isTail bool, str *string) {
+	if n.Children[1] != nil {
+		newPrefix := prefix
+		if isTail {
+			newPrefix += "│   "
+		} else {
+			newPrefix += "    "
+		}
+		output(n.Children[1], newPrefix, false, str)
+	}
+	*str += prefix
+	if isTail {
+		*str += "└── "
+	} else {
+		*str += "┌── "
+	}
+	*str += n.String() + "\n"
+	if n.Children[0] != nil {
+		newPrefix := prefix
+		if isTail {
+			newPrefix += "    "
+		} else {
+			newPrefix += "│   "
+		}
+		output(n.Children[0], newPrefix, true, str)
+	}
+}
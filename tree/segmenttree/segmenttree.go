@@ -0,0 +1,206 @@
+package segmenttree
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/util"
+)
+
+func assertTreeImplementation() {
+	var _ container.Container = (*Tree)(nil)
+}
+
+// Tree is a segment tree over a fixed number of elements. It answers Query(l, r), the combination of
+// the elements l..r in order, and assigns single elements or whole ranges, all in O(log n).
+// Range assignments are propagated lazily.
+type Tree struct {
+	Monoid util.Monoid
+	size   int
+	nodes  []interface{} // nodes[i]为节点i所覆盖区间的组合值
+	lazy   []interface{} // lazy[i]为尚未下推到孩子的赋值
+	marked []bool        // marked[i]表示lazy[i]有效
+}
+
+// NewWith returns a tree over values combined by monoid, it is built in O(n).
+func NewWith(monoid util.Monoid, values []interface{}) *Tree {
+	t := &Tree{Monoid: monoid}
+	t.build(values)
+	return t
+}
+
+func NewWithIntSum(values []int) *Tree {
+	return NewWith(util.IntSumMonoid, intsToValues(values))
+}
+
+func NewWithIntMin(values []int) *Tree {
+	return NewWith(util.IntMinMonoid, intsToValues(values))
+}
+
+func NewWithIntMax(values []int) *Tree {
+	return NewWith(util.IntMaxMonoid, intsToValues(values))
+}
+
+// Query returns the combination of the elements from index l to index r, both inclusive.
+// It returns the Identity if l > r and panics if l or r is out of range.
+func (t *Tree) Query(l int, r int) interface{} {
+	if l > r {
+		return t.Monoid.Identity
+	}
+	t.checkIndex(l)
+	t.checkIndex(r)
+	return t.query(1, 0, t.size-1, l, r)
+}
+
+// Get returns the element at index i.
+func (t *Tree) Get(i int) interface{} {
+	return t.Query(i, i)
+}
+
+// Update assigns value to the element at index i.
+func (t *Tree) Update(i int, value interface{}) {
+	t.RangeUpdate(i, i, value)
+}
+
+// RangeUpdate assigns value to every element from index l to index r, both inclusive.
+func (t *Tree) RangeUpdate(l int, r int, value interface{}) {
+	if l > r {
+		return
+	}
+	t.checkIndex(l)
+	t.checkIndex(r)
+	t.update(1, 0, t.size-1, l, r, value)
+}
+
+func (t *Tree) Empty() bool {
+	return t.size == 0
+}
+
+func (t *Tree) Size() int {
+	return t.size
+}
+
+// Clear removes all the elements.
+func (t *Tree) Clear() {
+	t.build(nil)
+}
+
+// Values returns the elements in index order.
+func (t *Tree) Values() []interface{} {
+	values := make([]interface{}, 0, t.size)
+	if t.size > 0 {
+		t.collect(1, 0, t.size-1, &values)
+	}
+	return values
+}
+
+func (t *Tree) String() string {
+	str := "SegmentTree\n"
+	values := []string{}
+	for _, value := range t.Values() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	return str
+}
+
+func (t *Tree) checkIndex(i int) {
+	if i < 0 || i >= t.size {
+		panic(fmt.Sprintf("Index out of range, %d not in [0, %d)", i, t.size))
+	}
+}
+
+func (t *Tree) build(values []interface{}) {
+	t.size = len(values)
+	t.nodes = make([]interface{}, 4*t.size)
+	t.lazy = make([]interface{}, 4*t.size)
+	t.marked = make([]bool, 4*t.size)
+	if t.size > 0 {
+		t.buildNode(1, 0, t.size-1, values)
+	}
+}
+
+// 节点i的孩子为2i和2i+1, 覆盖区间[lo, hi]
+func (t *Tree) buildNode(i int, lo int, hi int, values []interface{}) {
+	if lo == hi {
+		t.nodes[i] = values[lo]
+		return
+	}
+	mid := (lo + hi) / 2
+	t.buildNode(2*i, lo, mid, values)
+	t.buildNode(2*i+1, mid+1, hi, values)
+	t.nodes[i] = t.Monoid.Combine(t.nodes[2*i], t.nodes[2*i+1])
+}
+
+// assign把[lo, hi]的每个元素都赋值为value, 组合值为value自身组合hi-lo+1次
+func (t *Tree) assign(i int, lo int, hi int, value interface{}) {
+	t.nodes[i] = t.Monoid.Power(value, hi-lo+1)
+	if lo < hi {
+		t.lazy[i] = value
+		t.marked[i] = true
+	}
+}
+
+// push把节点i的赋值下推到孩子
+func (t *Tree) push(i int, lo int, hi int) {
+	if !t.marked[i] {
+		return
+	}
+	mid := (lo + hi) / 2
+	t.assign(2*i, lo, mid, t.lazy[i])
+	t.assign(2*i+1, mid+1, hi, t.lazy[i])
+	t.lazy[i] = nil
+	t.marked[i] = false
+}
+
+func (t *Tree) query(i int, lo int, hi int, l int, r int) interface{} {
+	if l <= lo && hi <= r {
+		return t.nodes[i]
+	}
+	t.push(i, lo, hi)
+	mid := (lo + hi) / 2
+	switch {
+	case r <= mid:
+		return t.query(2*i, lo, mid, l, r)
+	case l > mid:
+		return t.query(2*i+1, mid+1, hi, l, r)
+	default:
+		return t.Monoid.Combine(t.query(2*i, lo, mid, l, r), t.query(2*i+1, mid+1, hi, l, r))
+	}
+}
+
+func (t *Tree) update(i int, lo int, hi int, l int, r int, value interface{}) {
+	if l <= lo && hi <= r {
+		t.assign(i, lo, hi, value)
+		return
+	}
+	t.push(i, lo, hi)
+	mid := (lo + hi) / 2
+	if l <= mid {
+		t.update(2*i, lo, mid, l, r, value)
+	}
+	if r > mid {
+		t.update(2*i+1, mid+1, hi, l, r, value)
+	}
+	t.nodes[i] = t.Monoid.Combine(t.nodes[2*i], t.nodes[2*i+1])
+}
+
+func (t *Tree) collect(i int, lo int, hi int, values *[]interface{}) {
+	if lo == hi {
+		*values = append(*values, t.nodes[i])
+		return
+	}
+	t.push(i, lo, hi)
+	mid := (lo + hi) / 2
+	t.collect(2*i, lo, mid, values)
+	t.collect(2*i+1, mid+1, hi, values)
+}
+
+func intsToValues(ints []int) []interface{} {
+	values := make([]interface{}, len(ints))
+	for i, v := range ints {
+		values[i] = v
+	}
+	return values
+}
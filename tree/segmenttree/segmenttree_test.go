@@ -0,0 +1,191 @@
+package segmenttree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+func TestSegmentTreeQuery(t *testing.T) {
+	sum := NewWithIntSum([]int{5, 3, 8, 1, 9, 7})
+	min := NewWithIntMin([]int{5, 3, 8, 1, 9, 7})
+	max := NewWithIntMax([]int{5, 3, 8, 1, 9, 7})
+
+	tests := [][]interface{}{
+		{0, 5, 33, 1, 9},
+		{0, 0, 5, 5, 5},
+		{1, 2, 11, 3, 8},
+		{2, 4, 18, 1, 9},
+		{4, 5, 16, 7, 9},
+		{3, 2, 0, util.IntMinMonoid.Identity, util.IntMaxMonoid.Identity},
+	}
+	for _, test := range tests {
+		l, r := test[0].(int), test[1].(int)
+		if actualValue := sum.Query(l, r); actualValue != test[2] {
+			t.Errorf("Got %v expected %v", actualValue, test[2])
+		}
+		if actualValue := min.Query(l, r); actualValue != test[3] {
+			t.Errorf("Got %v expected %v", actualValue, test[3])
+		}
+		if actualValue := max.Query(l, r); actualValue != test[4] {
+			t.Errorf("Got %v expected %v", actualValue, test[4])
+		}
+	}
+}
+
+func TestSegmentTreeUpdate(t *testing.T) {
+	tree := NewWithIntSum([]int{1, 2, 3, 4, 5, 6, 7, 8})
+
+	tree.Update(3, 10)
+	if actualValue := tree.Query(0, 7); actualValue != 42 {
+		t.Errorf("Got %v expected %v", actualValue, 42)
+	}
+	tree.RangeUpdate(2, 6, 0)
+	if actualValue := tree.Query(0, 7); actualValue != 11 {
+		t.Errorf("Got %v expected %v", actualValue, 11)
+	}
+	tree.RangeUpdate(0, 3, 2)
+	if actualValue := tree.Query(1, 4); actualValue != 6 {
+		t.Errorf("Got %v expected %v", actualValue, 6)
+	}
+	tree.Update(5, 1)
+	if actualValue := fmt.Sprint(tree.Values()); actualValue != "[2 2 2 2 0 1 0 8]" {
+		t.Errorf("Got %v expected %v", actualValue, "[2 2 2 2 0 1 0 8]")
+	}
+	if actualValue := tree.Get(7); actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	if actualValue := tree.String(); actualValue != "SegmentTree\n2, 2, 2, 2, 0, 1, 0, 8" {
+		t.Errorf("Got %v expected %v", actualValue, "SegmentTree\n2, 2, 2, 2, 0, 1, 0, 8")
+	}
+
+	if actualValue := tree.Size(); actualValue != 8 {
+		t.Errorf("Got %v expected %v", actualValue, 8)
+	}
+	tree.Clear()
+	if actualValue := tree.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+	if actualValue := len(tree.Values()); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+}
+
+func TestSegmentTreeNonCommutative(t *testing.T) {
+	concat := util.Monoid{
+		Combine: func(a, b interface{}) interface{} {
+			return a.(string) + b.(string)
+		},
+		Identity: "",
+	}
+	tree := NewWith(concat, []interface{}{"a", "b", "c", "d", "e"})
+	tree.RangeUpdate(1, 3, "x")
+	if actualValue := tree.Query(0, 4); actualValue != "axxxe" {
+		t.Errorf("Got %v expected %v", actualValue, "axxxe")
+	}
+	tree.Update(2, "y")
+	if actualValue := tree.Query(1, 4); actualValue != "xyxe" {
+		t.Errorf("Got %v expected %v", actualValue, "xyxe")
+	}
+}
+
+func TestSegmentTreeIndexOutOfRange(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got %v expected %v", r, "panic")
+		}
+	}()
+	NewWithIntSum([]int{1, 2, 3}).Query(1, 3)
+}
+
+func TestSegmentTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	expected := make([]int, 300)
+	for i := range expected {
+		expected[i] = r.Intn(100)
+	}
+	trees := []*Tree{NewWithIntSum(expected), NewWithIntMin(expected), NewWithIntMax(expected)}
+
+	for i := 0; i < 2000; i++ {
+		l := r.Intn(len(expected))
+		h := l + r.Intn(len(expected)-l)
+		switch r.Intn(3) {
+		case 0:
+			value := r.Intn(100)
+			for j := l; j <= h; j++ {
+				expected[j] = value
+			}
+			for _, tree := range trees {
+				tree.RangeUpdate(l, h, value)
+			}
+		case 1:
+			expected[l] = r.Intn(100)
+			for _, tree := range trees {
+				tree.Update(l, expected[l])
+			}
+		default:
+			for _, tree := range trees {
+				expectedValue := tree.Monoid.Identity
+				for j := l; j <= h; j++ {
+					expectedValue = tree.Monoid.Combine(expectedValue, expected[j])
+				}
+				if actualValue := tree.Query(l, h); actualValue != expectedValue {
+					t.Errorf("Got %v expected %v", actualValue, expectedValue)
+				}
+			}
+		}
+	}
+	if actualValue, expectedValue := fmt.Sprint(trees[0].Values()), fmt.Sprint(expected); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func benchmarkQuery(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Query(n/2, n)
+		}
+	}
+}
+
+func benchmarkRangeUpdate(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.RangeUpdate(n/2, n, n)
+		}
+	}
+}
+
+func BenchmarkSegmentTreeQuery1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewWithIntSum(make([]int, size))
+	b.StartTimer()
+	benchmarkQuery(b, tree, size)
+}
+
+func BenchmarkSegmentTreeQuery100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntSum(make([]int, size))
+	b.StartTimer()
+	benchmarkQuery(b, tree, size)
+}
+
+func BenchmarkSegmentTreeRangeUpdate1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewWithIntSum(make([]int, size))
+	b.StartTimer()
+	benchmarkRangeUpdate(b, tree, size)
+}
+
+func BenchmarkSegmentTreeRangeUpdate100000(b *testing.B) {
+	b.StopTimer()
+	size := 100000
+	tree := NewWithIntSum(make([]int, size))
+	b.StartTimer()
+	benchmarkRangeUpdate(b, tree, size)
+}
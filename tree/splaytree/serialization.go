@@ -0,0 +1,49 @@
+package splaytree
+
+import (
+	"encoding/json"
+
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/util"
+)
+
+func assertSerializationImplementation() {
+	var _ container.JSONSerializer = (*Tree)(nil)
+	var _ container.JSONDeserializer = (*Tree)(nil)
+	var _ json.Marshaler = (*Tree)(nil)
+	var _ json.Unmarshaler = (*Tree)(nil)
+}
+
+// ToJSON encodes the tree as a JSON array of {key,value} pairs in key order.
+// The type of built-in keys is recorded so that FromJSON can restore it.
+func (t *Tree) ToJSON() ([]byte, error) {
+	return util.MarshalEntries(t.Keys(), t.Values())
+}
+
+// FromJSON replaces the contents of the tree with the output of ToJSON.
+// Keys of custom types are restored by t.KeyDecoder.
+// The tree must be created by a constructor, a zero value Tree returns util.ErrNotConstructed.
+func (t *Tree) FromJSON(data []byte) error {
+	if t.Comparator == nil {
+		return util.ErrNotConstructed
+	}
+	keys, values, err := util.UnmarshalEntries(data, t.KeyDecoder)
+	if err != nil {
+		return err
+	}
+	t.Clear()
+	for i, key := range keys {
+		t.Put(key, values[i])
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, it is equivalent to ToJSON.
+func (t *Tree) MarshalJSON() ([]byte, error) {
+	return t.ToJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it is equivalent to FromJSON.
+func (t *Tree) UnmarshalJSON(data []byte) error {
+	return t.FromJSON(data)
+}
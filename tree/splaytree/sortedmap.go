@@ -0,0 +1,36 @@
+package splaytree
+
+import "github.com/morganxf/algorithm/container"
+
+func assertSortedMapImplementation() {
+	var _ container.SortedMap = (*Tree)(nil)
+}
+
+func (t *Tree) Min() (key interface{}, value interface{}, found bool) {
+	return entry(t.Left())
+}
+
+func (t *Tree) Max() (key interface{}, value interface{}, found bool) {
+	return entry(t.Right())
+}
+
+func (t *Tree) FloorEntry(key interface{}) (floorKey interface{}, floorValue interface{}, found bool) {
+	node, _ := t.Floor(key)
+	return entry(node)
+}
+
+func (t *Tree) CeilingEntry(key interface{}) (ceilingKey interface{}, ceilingValue interface{}, found bool) {
+	node, _ := t.Ceiling(key)
+	return entry(node)
+}
+
+func (t *Tree) EntryIterator() container.ReverseIteratorWithKey {
+	return t.Iterator()
+}
+
+func entry(node *Node) (key interface{}, value interface{}, found bool) {
+	if node == nil {
+		return nil, nil, false
+	}
+	return node.Key, node.Value, true
+}
@@ -0,0 +1,360 @@
+package splaytree
+
+import (
+	"fmt"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+// Tree is a splay tree. Put, Get, Remove, Floor and Ceiling move the accessed node to the root,
+// so recently accessed keys are found faster; every operation is O(log n) amortized.
+// Since lookups modify the tree, it is not safe for concurrent readers. Peek, Left, Right and
+// the iterator do not restructure the tree.
+type Tree struct {
+	Root       *Node
+	Comparator util.Comparator
+	KeyDecoder util.KeyDecoder // 可选, FromJSON用于还原自定义类型的key
+	size       int
+}
+
+type Node struct {
+	Key      interface{}
+	Value    interface{}
+	Parent   *Node
+	Children [2]*Node // Left - Right
+}
+
+func NewWith(comparator util.Comparator) *Tree {
+	return &Tree{Comparator: comparator}
+}
+
+func NewWithIntComparator() *Tree {
+	return &Tree{Comparator: util.IntComparator}
+}
+
+func NewWithStringComparator() *Tree {
+	return &Tree{Comparator: util.StringComparator}
+}
+
+func (t *Tree) Put(key interface{}, value interface{}) {
+	if t.Root == nil {
+		t.Root = &Node{Key: key, Value: value}
+		t.size++
+		return
+	}
+	cur := t.Root
+	for {
+		cmp := t.Comparator(key, cur.Key)
+		if cmp == 0 {
+			cur.Key = key
+			cur.Value = value
+			t.splay(cur)
+			return
+		}
+		d := 0
+		if cmp > 0 {
+			d = 1
+		}
+		if cur.Children[d] == nil {
+			node := &Node{Key: key, Value: value, Parent: cur}
+			cur.Children[d] = node
+			t.size++
+			t.splay(node)
+			return
+		}
+		cur = cur.Children[d]
+	}
+}
+
+// Get returns the value of key and moves its node to the root.
+func (t *Tree) Get(key interface{}) (interface{}, bool) {
+	node, last := t.lookup(key)
+	t.splay(last)
+	if node == nil {
+		return nil, false
+	}
+	return node.Value, true
+}
+
+// Peek returns the value of key like Get, without restructuring the tree.
+func (t *Tree) Peek(key interface{}) (interface{}, bool) {
+	node, _ := t.lookup(key)
+	if node == nil {
+		return nil, false
+	}
+	return node.Value, true
+}
+
+func (t *Tree) Remove(key interface{}) {
+	node, last := t.lookup(key)
+	t.splay(last)
+	if node == nil {
+		return
+	}
+	// node已经是根, 用左子树的最大node连接左右子树
+	left, right := node.Children[0], node.Children[1]
+	if left == nil {
+		t.Root = right
+	} else {
+		left.Parent = nil
+		max := left
+		for max.Children[1] != nil {
+			max = max.Children[1]
+		}
+		t.Root = left
+		t.splay(max)
+		// max是左子树的最大node, 伸展后没有右孩子
+		max.Children[1] = right
+		if right != nil {
+			right.Parent = max
+		}
+	}
+	if t.Root != nil {
+		t.Root.Parent = nil
+	}
+	t.size--
+}
+
+// Left returns the node with the smallest key without restructuring the tree.
+func (t *Tree) Left() *Node {
+	return t.bottom(0)
+}
+
+// Right returns the node with the largest key without restructuring the tree.
+func (t *Tree) Right() *Node {
+	return t.bottom(1)
+}
+
+// Floor returns the node with the largest key less than or equal to the given key and moves it to the root.
+func (t *Tree) Floor(key interface{}) (*Node, bool) {
+	var floor, last *Node
+	cur := t.Root
+	for cur != nil {
+		last = cur
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp < 0:
+			cur = cur.Children[0]
+		case cmp > 0:
+			floor = cur
+			cur = cur.Children[1]
+		default:
+			floor, cur = cur, nil
+		}
+	}
+	if floor != nil {
+		last = floor
+	}
+	t.splay(last)
+	return floor, floor != nil
+}
+
+// Ceiling returns the node with the smallest key greater than or equal to the given key and moves it to the root.
+func (t *Tree) Ceiling(key interface{}) (*Node, bool) {
+	var ceiling, last *Node
+	cur := t.Root
+	for cur != nil {
+		last = cur
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp < 0:
+			ceiling = cur
+			cur = cur.Children[0]
+		case cmp > 0:
+			cur = cur.Children[1]
+		default:
+			ceiling, cur = cur, nil
+		}
+	}
+	if ceiling != nil {
+		last = ceiling
+	}
+	t.splay(last)
+	return ceiling, ceiling != nil
+}
+
+func (t *Tree) Empty() bool {
+	return t.size == 0
+}
+
+func (t *Tree) Size() int {
+	return t.size
+}
+
+func (t *Tree) Keys() []interface{} {
+	it := t.Iterator()
+	keys := make([]interface{}, 0, t.size)
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+func (t *Tree) Values() []interface{} {
+	it := t.Iterator()
+	values := make([]interface{}, 0, t.size)
+	for it.Next() {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
+func (t *Tree) Clear() {
+	t.Root = nil
+	t.size = 0
+}
+
+func (t *Tree) String() string {
+	str := "SplayTree\n"
+	if !t.Empty() {
+		output(t.Root, "", true, &str)
+	}
+	return str
+}
+
+// lookup返回key所在的node以及查找路径上的最后一个node, 不伸展
+func (t *Tree) lookup(key interface{}) (node *Node, last *Node) {
+	cur := t.Root
+	for cur != nil {
+		last = cur
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp == 0:
+			return cur, cur
+		case cmp < 0:
+			cur = cur.Children[0]
+		case cmp > 0:
+			cur = cur.Children[1]
+		}
+	}
+	return nil, last
+}
+
+func (t *Tree) bottom(d int) *Node {
+	if t.Root == nil {
+		return nil
+	}
+	var cur = t.Root
+	for ; cur.Children[d] != nil; cur = cur.Children[d] {
+	}
+	return cur
+}
+
+// splay通过zig, zig-zig, zig-zag旋转把node移动到根, node为nil时什么也不做
+func (t *Tree) splay(node *Node) {
+	if node == nil {
+		return
+	}
+	for node.Parent != nil {
+		parent := node.Parent
+		switch {
+		case parent.Parent == nil:
+			// zig
+			rotate(node)
+		case node.direction() == parent.direction():
+			// zig-zig: 先旋转parent
+			rotate(parent)
+			rotate(node)
+		default:
+			// zig-zag
+			rotate(node)
+			rotate(node)
+		}
+	}
+	t.Root = node
+}
+
+// rotate把node旋转到parent的位置
+func rotate(node *Node) {
+	parent, grandparent := node.Parent, node.Parent.Parent
+	d := node.direction()
+	parent.Children[d] = node.Children[d^1]
+	if parent.Children[d] != nil {
+		parent.Children[d].Parent = parent
+	}
+	if grandparent != nil {
+		grandparent.Children[parent.direction()] = node
+	}
+	node.Children[d^1] = parent
+	node.Parent = grandparent
+	parent.Parent = node
+}
+
+func (n *Node) Left() *Node {
+	return n.Children[0]
+}
+
+func (n *Node) Right() *Node {
+	return n.Children[1]
+}
+
+func (n *Node) Next() *Node {
+	return n.walk(1)
+}
+
+func (n *Node) Prev() *Node {
+	return n.walk(0)
+}
+
+func (n *Node) String() string {
+	return fmt.Sprintf("%v", n.Key)
+}
+
+// direction返回n是parent的左孩子(0)还是右孩子(1)
+func (n *Node) direction() int {
+	if n.Parent.Children[0] == n {
+		return 0
+	}
+	return 1
+}
+
+// 如果d==1, 则代表寻找第一个比之大的节点。为当前节点右子孩子中最小的节点或者祖先节点的第一个比之大的右孩子
+// 如果d==0, 则代表寻找第一个比之小的节点。为当前节点左子孩子中最大的节点或者祖先节点的第一个比之小的左孩子
+func (n *Node) walk(d int) *Node {
+	if n == nil {
+		return nil
+	}
+	cur := n
+	if cur.Children[d] == nil {
+		parent := cur.Parent
+		for parent != nil && parent.Children[d] == cur {
+			cur = parent
+			parent = parent.Parent
+		}
+		return parent
+	}
+	child := cur.Children[d]
+	for child.Children[d^1] != nil {
+		child = child.Children[d^1]
+	}
+	return child
+}
+
+// 格式化的后置遍历, 与avltree的输出格式相同
+func output(n *Node, prefix string, isTail bool, str *string) {
+	if n.Children[1] != nil {
+		newPrefix := prefix
+		if isTail {
+			newPrefix += "│   "
+		} else {
+			newPrefix += "    "
+		}
+		output(n.Children[1], newPrefix, false, str)
+	}
+	*str += prefix
+	if isTail {
+		*str += "└── "
+	} else {
+		*str += "┌── "
+	}
+	*str += n.String() + "\n"
+	if n.Children[0] != nil {
+		newPrefix := prefix
+		if isTail {
+			newPrefix += "    "
+		} else {
+			newPrefix += "│   "
+		}
+		output(n.Children[0], newPrefix, true, str)
+	}
+}
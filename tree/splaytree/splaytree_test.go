@@ -0,0 +1,268 @@
+package splaytree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/container/containertest"
+)
+
+func TestSplayTreePutGetRemove(t *testing.T) {
+	tree := NewWithIntComparator()
+	tree.Put(5, "e")
+	tree.Put(6, "f")
+	tree.Put(7, "g")
+	tree.Put(3, "c")
+	tree.Put(4, "d")
+	tree.Put(1, "x")
+	tree.Put(2, "b")
+	tree.Put(1, "a") //overwrite
+
+	if actualValue := tree.Size(); actualValue != 7 {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys(), tree.Values()), "[1 2 3 4 5 6 7] [a b c d e f g]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tests := [][]interface{}{
+		{0, nil, false},
+		{1, "a", true},
+		{4, "d", true},
+		{7, "g", true},
+		{8, nil, false},
+	}
+	for _, test := range tests {
+		if value, found := tree.Get(test[0]); value != test[1] || found != test[2] {
+			t.Errorf("Got %v,%v expected %v,%v", value, found, test[1], test[2])
+		}
+	}
+
+	tree.Remove(5)
+	tree.Remove(1)
+	tree.Remove(8)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[2 3 4 6 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if left, right := tree.Left(), tree.Right(); left.Key != 2 || right.Key != 7 {
+		t.Errorf("Got %v,%v expected %v,%v", left, right, 2, 7)
+	}
+	if node, found := tree.Floor(5); node.Key != 4 || !found {
+		t.Errorf("Got %v expected %v", node, 4)
+	}
+	if node, found := tree.Ceiling(5); node.Key != 6 || !found {
+		t.Errorf("Got %v expected %v", node, 6)
+	}
+	if node, found := tree.Floor(1); node != nil || found {
+		t.Errorf("Got %v expected %v", node, "<nil>")
+	}
+	assertValidSplayTree(t, tree)
+
+	for _, key := range []int{2, 3, 4, 6, 7} {
+		tree.Remove(key)
+	}
+	if empty, root := tree.Empty(), tree.Root; !empty || root != nil {
+		t.Errorf("Got %v,%v expected %v,%v", empty, root, true, nil)
+	}
+}
+
+func TestSplayTreePutEqualKey(t *testing.T) {
+	type key struct {
+		id      int
+		version int
+	}
+	tree := NewWith(func(a, b interface{}) int {
+		return a.(key).id - b.(key).id
+	})
+	tree.Put(key{2, 1}, "b")
+	tree.Put(key{1, 1}, "a")
+	tree.Put(key{3, 1}, "c")
+	tree.Put(key{1, 2}, "x") // 相等的key也替换key本身
+
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys(), tree.Values()), "[{1 2} {2 1} {3 1}] [x b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestSplayTreeAccessMovesToRoot(t *testing.T) {
+	tree := NewWithIntComparator()
+	for key := 1; key <= 10; key++ {
+		tree.Put(key, key)
+		if actualValue := tree.Root.Key; actualValue != key {
+			t.Errorf("Got %v expected %v", actualValue, key)
+		}
+	}
+
+	tree.Get(3)
+	if actualValue := tree.Root.Key; actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	tree.Floor(5)
+	if actualValue := tree.Root.Key; actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	tree.Ceiling(11)
+	if actualValue := tree.Root.Key; actualValue != 10 {
+		t.Errorf("Got %v expected %v", actualValue, 10)
+	}
+	// 不存在的key把查找路径上的最后一个node移动到根
+	tree.Get(0)
+	if actualValue := tree.Root.Key; actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	assertValidSplayTree(t, tree)
+}
+
+func TestSplayTreePeekAndIterationKeepStructure(t *testing.T) {
+	tree := NewWithIntComparator()
+	for key := 1; key <= 10; key++ {
+		tree.Put(key, key)
+	}
+	before := tree.String()
+
+	if value, found := tree.Peek(3); value != 3 || !found {
+		t.Errorf("Got %v,%v expected %v,%v", value, found, 3, true)
+	}
+	if value, found := tree.Peek(11); value != nil || found {
+		t.Errorf("Got %v,%v expected %v,%v", value, found, nil, false)
+	}
+	it := tree.Iterator()
+	for it.Next() {
+	}
+	for it.Prev() {
+	}
+	tree.Left()
+	tree.Right()
+	tree.Keys()
+
+	if after := tree.String(); after != before {
+		t.Errorf("Got %v expected %v", after, before)
+	}
+}
+
+func TestSplayTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := NewWithIntComparator()
+	expected := make(map[int]int)
+	for i := 0; i < 5000; i++ {
+		key := r.Intn(1000)
+		switch r.Intn(3) {
+		case 0:
+			tree.Put(key, i)
+			expected[key] = i
+		case 1:
+			tree.Remove(key)
+			delete(expected, key)
+		default:
+			value, found := tree.Get(key)
+			if expectedValue, ok := expected[key]; found != ok || (ok && value != expectedValue) {
+				t.Errorf("Got %v,%v expected %v,%v", value, found, expectedValue, ok)
+			}
+		}
+		if i%100 == 0 {
+			assertValidSplayTree(t, tree)
+		}
+	}
+	assertValidSplayTree(t, tree)
+	if actualValue := tree.Size(); actualValue != len(expected) {
+		t.Errorf("Got %v expected %v", actualValue, len(expected))
+	}
+}
+
+// assertValidSplayTree检查key的顺序、parent指针以及节点数目
+func assertValidSplayTree(t *testing.T, tree *Tree) {
+	if tree.Root != nil && tree.Root.Parent != nil {
+		t.Errorf("Got root %v with parent %v", tree.Root, tree.Root.Parent)
+	}
+	var check func(n *Node) int
+	check = func(n *Node) int {
+		if n == nil {
+			return 0
+		}
+		for d, child := range n.Children {
+			if child == nil {
+				continue
+			}
+			if child.Parent != n {
+				t.Errorf("Got parent %v expected %v", child.Parent, n)
+			}
+			if cmp := tree.Comparator(child.Key, n.Key); (d == 0 && cmp >= 0) || (d == 1 && cmp <= 0) {
+				t.Errorf("Got child %v on the wrong side of %v", child, n)
+			}
+		}
+		return 1 + check(n.Children[0]) + check(n.Children[1])
+	}
+	if actualValue := check(tree.Root); actualValue != tree.Size() {
+		t.Errorf("Got %v expected %v", actualValue, tree.Size())
+	}
+}
+
+func TestSplayTreeSortedMap(t *testing.T) {
+	containertest.TestSortedMap(t, func() container.SortedMap { return NewWithIntComparator() })
+}
+
+func TestSplayTreeSerialization(t *testing.T) {
+	tree := NewWithStringComparator()
+	tree.Put("c", 3)
+	tree.Put("a", 1)
+	tree.Put("b", 2)
+
+	json, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	restored := NewWithStringComparator()
+	if err := restored.FromJSON(json); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(restored.Keys(), restored.Values()), "[a b c] [1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func benchmarkGet(b *testing.B, tree *Tree, keys []int) {
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			tree.Get(key)
+		}
+	}
+}
+
+// 90%的访问集中在10个key上
+func skewedKeys(size int) []int {
+	r := rand.New(rand.NewSource(1))
+	keys := make([]int, size)
+	for i := range keys {
+		if r.Intn(10) == 0 {
+			keys[i] = r.Intn(size)
+		} else {
+			keys[i] = r.Intn(10) * (size / 10)
+		}
+	}
+	return keys
+}
+
+func BenchmarkSplayTreeGetSkewed10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	tree := NewWithIntComparator()
+	for _, n := range rand.New(rand.NewSource(2)).Perm(size) {
+		tree.Put(n, struct{}{})
+	}
+	keys := skewedKeys(size)
+	b.StartTimer()
+	benchmarkGet(b, tree, keys)
+}
+
+func BenchmarkSplayTreeGetUniform10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	tree := NewWithIntComparator()
+	for _, n := range rand.New(rand.NewSource(2)).Perm(size) {
+		tree.Put(n, struct{}{})
+	}
+	keys := rand.New(rand.NewSource(3)).Perm(size)
+	b.StartTimer()
+	benchmarkGet(b, tree, keys)
+}
@@ -0,0 +1,79 @@
+package treap
+
+// Union moves the entries of other into t. For keys present in both trees the value of other wins,
+// as if every entry of other had been Put into t. other is empty afterwards.
+// It runs in O(m log(n/m + 1)) expected time, where m <= n are the sizes of the two trees.
+func (t *Tree) Union(other *Tree) {
+	t.Root, other.Root = t.union(t.Root, other.Root, true), nil
+}
+
+// Intersection keeps in t only the keys that are also in other, with the values of t.
+// other is empty afterwards. It runs in O(m log(n/m + 1)) expected time.
+func (t *Tree) Intersection(other *Tree) {
+	t.Root, other.Root = t.intersection(t.Root, other.Root), nil
+}
+
+// Difference removes from t the keys that are in other. other is empty afterwards.
+// It runs in O(m log(n/m + 1)) expected time.
+func (t *Tree) Difference(other *Tree) {
+	t.Root, other.Root = t.difference(t.Root, other.Root), nil
+}
+
+// union以两棵子树中优先级较高的根为中心, 用它的key分割另一棵子树, 再递归合并左右两侧
+// preferB: key相同时是否使用b中的value
+func (t *Tree) union(a *Node, b *Node, preferB bool) *Node {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority < b.priority {
+		a, b, preferB = b, a, !preferB
+	}
+	less, equal, greater := t.split(b, a.Key)
+	if equal != nil && preferB {
+		a.Value = equal.Value
+	}
+	a.Children[0] = t.union(a.Children[0], less, preferB)
+	a.Children[1] = t.union(a.Children[1], greater, preferB)
+	a.resize()
+	return a
+}
+
+// intersection保留a中的value; 仍以优先级较高的根为中心, 才能保持堆的性质
+func (t *Tree) intersection(a *Node, b *Node) *Node {
+	if a == nil || b == nil {
+		return nil
+	}
+	if a.priority >= b.priority {
+		less, equal, greater := t.split(b, a.Key)
+		left := t.intersection(a.Children[0], less)
+		right := t.intersection(a.Children[1], greater)
+		if equal == nil {
+			return merge(left, right)
+		}
+		a.Children = [2]*Node{left, right}
+		a.resize()
+		return a
+	}
+	less, equal, greater := t.split(a, b.Key)
+	left := t.intersection(less, b.Children[0])
+	right := t.intersection(greater, b.Children[1])
+	if equal == nil {
+		return merge(left, right)
+	}
+	// 使用b的node以保持优先级, key和value取自a
+	b.Key, b.Value = equal.Key, equal.Value
+	b.Children = [2]*Node{left, right}
+	b.resize()
+	return b
+}
+
+func (t *Tree) difference(a *Node, b *Node) *Node {
+	if a == nil || b == nil {
+		return a
+	}
+	less, _, greater := t.split(a, b.Key)
+	return merge(t.difference(less, b.Children[0]), t.difference(greater, b.Children[1]))
+}
@@ -0,0 +1,305 @@
+package treap
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/morganxf/algorithm/util"
+)
+
+// Tree is a treap: a binary search tree on the keys and a max-heap on random node priorities,
+// so that its shape is that of a BST built by inserting the keys in random order.
+// Every update is expressed with the split and merge primitives.
+type Tree struct {
+	Root       *Node
+	Comparator util.Comparator
+	KeyDecoder util.KeyDecoder // 可选, FromJSON用于还原自定义类型的key
+	rand       *rand.Rand
+}
+
+type Node struct {
+	Key      interface{}
+	Value    interface{}
+	Children [2]*Node // Left - Right
+	priority uint64
+	size     int // 以该节点为根的子树的节点数目
+}
+
+func NewWith(comparator util.Comparator) *Tree {
+	return NewWithSeed(comparator, time.Now().UnixNano())
+}
+
+func NewWithIntComparator() *Tree {
+	return NewWith(util.IntComparator)
+}
+
+func NewWithStringComparator() *Tree {
+	return NewWith(util.StringComparator)
+}
+
+// NewWithSeed returns an empty treap whose priorities are drawn from a generator seeded with seed.
+// Treaps built by the same operations with the same seed have the same shape.
+func NewWithSeed(comparator util.Comparator, seed int64) *Tree {
+	return &Tree{Comparator: comparator, rand: rand.New(rand.NewSource(seed))}
+}
+
+// Put inserts or updates key. A Tree created without a constructor draws its priorities from a time-seeded generator.
+func (t *Tree) Put(key interface{}, value interface{}) {
+	less, equal, greater := t.split(t.Root, key)
+	if equal != nil {
+		equal.Key = key
+		equal.Value = value
+	} else {
+		if t.rand == nil {
+			t.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+		}
+		equal = &Node{Key: key, Value: value, priority: t.rand.Uint64(), size: 1}
+	}
+	t.Root = merge(merge(less, equal), greater)
+}
+
+func (t *Tree) Get(key interface{}) (interface{}, bool) {
+	cur := t.Root
+	for cur != nil {
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp == 0:
+			return cur.Value, true
+		case cmp < 0:
+			cur = cur.Children[0]
+		case cmp > 0:
+			cur = cur.Children[1]
+		}
+	}
+	return nil, false
+}
+
+func (t *Tree) Remove(key interface{}) {
+	less, _, greater := t.split(t.Root, key)
+	t.Root = merge(less, greater)
+}
+
+func (t *Tree) Left() *Node {
+	return t.bottom(0)
+}
+
+func (t *Tree) Right() *Node {
+	return t.bottom(1)
+}
+
+// Floor returns the node with the largest key less than or equal to the given key.
+func (t *Tree) Floor(key interface{}) (*Node, bool) {
+	var floor *Node
+	cur := t.Root
+	for cur != nil {
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp < 0:
+			cur = cur.Children[0]
+		case cmp > 0:
+			floor = cur
+			cur = cur.Children[1]
+		default:
+			return cur, true
+		}
+	}
+	return floor, floor != nil
+}
+
+// Ceiling returns the node with the smallest key greater than or equal to the given key.
+func (t *Tree) Ceiling(key interface{}) (*Node, bool) {
+	var ceiling *Node
+	cur := t.Root
+	for cur != nil {
+		cmp := t.Comparator(key, cur.Key)
+		switch {
+		case cmp < 0:
+			ceiling = cur
+			cur = cur.Children[0]
+		case cmp > 0:
+			cur = cur.Children[1]
+		default:
+			return cur, true
+		}
+	}
+	return ceiling, ceiling != nil
+}
+
+func (t *Tree) Empty() bool {
+	return t.Root == nil
+}
+
+func (t *Tree) Size() int {
+	return t.Root.Size()
+}
+
+func (t *Tree) Keys() []interface{} {
+	it := t.Iterator()
+	keys := make([]interface{}, 0, t.Size())
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+func (t *Tree) Values() []interface{} {
+	it := t.Iterator()
+	values := make([]interface{}, 0, t.Size())
+	for it.Next() {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
+func (t *Tree) Clear() {
+	t.Root = nil
+}
+
+// Split cuts the tree at key in O(log n) expected time. The first returned tree holds the keys less than key,
+// the second one the keys greater than or equal to key. Nodes are moved rather than copied, so t is empty afterwards.
+func (t *Tree) Split(key interface{}) (*Tree, *Tree) {
+	less, equal, greater := t.split(t.Root, key)
+	t.Root = nil
+	return t.with(less), t.with(merge(equal, greater))
+}
+
+// Merge moves all the nodes of other into t in O(log n) expected time. The key ranges of the two trees
+// must not overlap, either tree may hold the smaller keys. other is empty afterwards.
+func (t *Tree) Merge(other *Tree) {
+	if other.Empty() {
+		return
+	}
+	if t.Empty() {
+		t.Root, other.Root = other.Root, nil
+		return
+	}
+	left, right := t.Root, other.Root
+	if t.Comparator(t.Right().Key, other.Left().Key) >= 0 {
+		if t.Comparator(other.Right().Key, t.Left().Key) >= 0 {
+			panic("Overlapping key ranges, cannot merge")
+		}
+		left, right = right, left
+	}
+	t.Root, other.Root = merge(left, right), nil
+}
+
+func (t *Tree) String() string {
+	str := "Treap\n"
+	if !t.Empty() {
+		output(t.Root, "", true, &str)
+	}
+	return str
+}
+
+// with返回以root为根、与t共用comparator和优先级生成器的treap
+func (t *Tree) with(root *Node) *Tree {
+	return &Tree{Root: root, Comparator: t.Comparator, KeyDecoder: t.KeyDecoder, rand: t.rand}
+}
+
+// split把子树n分为小于key、等于key和大于key三部分, 等于key的node不存在时为nil
+func (t *Tree) split(n *Node, key interface{}) (*Node, *Node, *Node) {
+	if n == nil {
+		return nil, nil, nil
+	}
+	cmp := t.Comparator(key, n.Key)
+	switch {
+	case cmp < 0:
+		less, equal, greater := t.split(n.Children[0], key)
+		n.Children[0] = greater
+		n.resize()
+		return less, equal, n
+	case cmp > 0:
+		less, equal, greater := t.split(n.Children[1], key)
+		n.Children[1] = less
+		n.resize()
+		return n, equal, greater
+	default:
+		less, greater := n.Children[0], n.Children[1]
+		n.Children = [2]*Node{}
+		n.resize()
+		return less, n, greater
+	}
+}
+
+// merge连接left和right两棵子树, left中的key都小于right中的key; 优先级高的node作为根
+func merge(left *Node, right *Node) *Node {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case left.priority > right.priority:
+		left.Children[1] = merge(left.Children[1], right)
+		left.resize()
+		return left
+	default:
+		right.Children[0] = merge(left, right.Children[0])
+		right.resize()
+		return right
+	}
+}
+
+func (t *Tree) bottom(d int) *Node {
+	if t.Root == nil {
+		return nil
+	}
+	var cur = t.Root
+	for ; cur.Children[d] != nil; cur = cur.Children[d] {
+	}
+	return cur
+}
+
+func (n *Node) Left() *Node {
+	return n.Children[0]
+}
+
+func (n *Node) Right() *Node {
+	return n.Children[1]
+}
+
+// Size returns the number of nodes in the subtree rooted at n.
+func (n *Node) Size() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *Node) String() string {
+	return fmt.Sprintf("%v", n.Key)
+}
+
+// 根据孩子重新计算子树节点数目
+func (n *Node) resize() {
+	n.size = 1 + n.Children[0].Size() + n.Children[1].Size()
+}
+
+// 格式化的后置遍历, 与avltree的输出格式相同
+func output(n *Node, prefix string, isTail bool, str *string) {
+	if n.Children[1] != nil {
+		newPrefix := prefix
+		if isTail {
+			newPrefix += "│   "
+		} else {
+			newPrefix += "    "
+		}
+		output(n.Children[1], newPrefix, false, str)
+	}
+	*str += prefix
+	if isTail {
+		*str += "└── "
+	} else {
+		*str += "┌── "
+	}
+	*str += n.String() + "\n"
+	if n.Children[0] != nil {
+		newPrefix := prefix
+		if isTail {
+			newPrefix += "    "
+		} else {
+			newPrefix += "│   "
+		}
+		output(n.Children[0], newPrefix, true, str)
+	}
+}
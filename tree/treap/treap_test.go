@@ -0,0 +1,429 @@
+package treap
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/morganxf/algorithm/container"
+	"github.com/morganxf/algorithm/container/containertest"
+	"github.com/morganxf/algorithm/util"
+)
+
+func TestTreapPutGetRemove(t *testing.T) {
+	tree := NewWithIntComparator()
+	tree.Put(5, "e")
+	tree.Put(6, "f")
+	tree.Put(7, "g")
+	tree.Put(3, "c")
+	tree.Put(4, "d")
+	tree.Put(1, "x")
+	tree.Put(2, "b")
+	tree.Put(1, "a") //overwrite
+
+	if actualValue := tree.Size(); actualValue != 7 {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys(), tree.Values()), "[1 2 3 4 5 6 7] [a b c d e f g]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tests := [][]interface{}{
+		{0, nil, false},
+		{1, "a", true},
+		{4, "d", true},
+		{7, "g", true},
+		{8, nil, false},
+	}
+	for _, test := range tests {
+		if value, found := tree.Get(test[0]); value != test[1] || found != test[2] {
+			t.Errorf("Got %v,%v expected %v,%v", value, found, test[1], test[2])
+		}
+	}
+
+	tree.Remove(5)
+	tree.Remove(1)
+	tree.Remove(8)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[2 3 4 6 7]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if left, right := tree.Left(), tree.Right(); left.Key != 2 || right.Key != 7 {
+		t.Errorf("Got %v,%v expected %v,%v", left, right, 2, 7)
+	}
+	if node, found := tree.Floor(5); node.Key != 4 || !found {
+		t.Errorf("Got %v expected %v", node, 4)
+	}
+	if node, found := tree.Ceiling(5); node.Key != 6 || !found {
+		t.Errorf("Got %v expected %v", node, 6)
+	}
+	if node, found := tree.Ceiling(8); node != nil || found {
+		t.Errorf("Got %v expected %v", node, "<nil>")
+	}
+	assertValidTreap(t, tree)
+}
+
+func TestTreapPutEqualKey(t *testing.T) {
+	type key struct {
+		id      int
+		version int
+	}
+	tree := NewWith(func(a, b interface{}) int {
+		return a.(key).id - b.(key).id
+	})
+	tree.Put(key{2, 1}, "b")
+	tree.Put(key{1, 1}, "a")
+	tree.Put(key{3, 1}, "c")
+	tree.Put(key{1, 2}, "x") // 相等的key也替换key本身
+
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys(), tree.Values()), "[{1 2} {2 1} {3 1}] [x b c]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestTreapSeed(t *testing.T) {
+	build := func(seed int64) *Tree {
+		tree := NewWithSeed(func(a, b interface{}) int { return a.(int) - b.(int) }, seed)
+		for key := 0; key < 32; key++ {
+			tree.Put(key, nil)
+		}
+		return tree
+	}
+	if first, second := build(7).String(), build(7).String(); first != second {
+		t.Errorf("Got %v expected %v", first, second)
+	}
+	if first, second := build(7).String(), build(8).String(); first == second {
+		t.Errorf("Got the same shape for different seeds %v", first)
+	}
+}
+
+func TestTreapZeroValue(t *testing.T) {
+	tree := &Tree{Comparator: util.IntComparator}
+	for _, key := range []int{3, 1, 2} {
+		tree.Put(key, key)
+	}
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	assertValidTreap(t, tree)
+}
+
+func TestTreapSplitAndMerge(t *testing.T) {
+	tree := NewWithSeed(func(a, b interface{}) int { return a.(int) - b.(int) }, 1)
+	for key := 0; key < 10; key++ {
+		tree.Put(key, key)
+	}
+	less, greater := tree.Split(4)
+	if actualValue, expectedValue := fmt.Sprint(less.Keys(), greater.Keys(), tree.Size()), "[0 1 2 3] [4 5 6 7 8 9] 0"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	assertValidTreap(t, less)
+	assertValidTreap(t, greater)
+
+	greater.Merge(less)
+	if actualValue, expectedValue := fmt.Sprint(greater.Keys(), less.Size()), "[0 1 2 3 4 5 6 7 8 9] 0"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	assertValidTreap(t, greater)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Got no panic merging overlapping trees")
+		}
+	}()
+	other := NewWithIntComparator()
+	other.Put(5, 5)
+	greater.Merge(other)
+}
+
+func TestTreapSetOperations(t *testing.T) {
+	newTree := func(keys []int, value string) *Tree {
+		tree := NewWithSeed(func(a, b interface{}) int { return a.(int) - b.(int) }, int64(len(keys)))
+		for _, key := range keys {
+			tree.Put(key, value)
+		}
+		return tree
+	}
+
+	a, b := newTree([]int{1, 2, 3, 4}, "a"), newTree([]int{3, 4, 5}, "b")
+	a.Union(b)
+	if actualValue, expectedValue := fmt.Sprint(a.Keys(), a.Values(), b.Size()), "[1 2 3 4 5] [a a b b b] 0"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	a, b = newTree([]int{1, 2, 3, 4}, "a"), newTree([]int{3, 4, 5}, "b")
+	a.Intersection(b)
+	if actualValue, expectedValue := fmt.Sprint(a.Keys(), a.Values(), b.Size()), "[3 4] [a a] 0"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	a, b = newTree([]int{1, 2, 3, 4}, "a"), newTree([]int{3, 4, 5}, "b")
+	a.Difference(b)
+	if actualValue, expectedValue := fmt.Sprint(a.Keys(), a.Values(), b.Size()), "[1 2] [a a] 0"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestTreapSetOperationsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		keysA, keysB := r.Perm(200)[:r.Intn(200)], r.Perm(200)[:r.Intn(200)]
+		inB := make(map[int]bool)
+		for _, key := range keysB {
+			inB[key] = true
+		}
+		var union, intersection, difference []int
+		for _, key := range keysB {
+			union = append(union, key)
+		}
+		for _, key := range keysA {
+			if inB[key] {
+				intersection = append(intersection, key)
+			} else {
+				union = append(union, key)
+				difference = append(difference, key)
+			}
+		}
+
+		tests := []struct {
+			operation func(a *Tree, b *Tree)
+			expected  []int
+		}{
+			{(*Tree).Union, union},
+			{(*Tree).Intersection, intersection},
+			{(*Tree).Difference, difference},
+		}
+		for _, test := range tests {
+			a, b := NewWithSeed(func(a, b interface{}) int { return a.(int) - b.(int) }, r.Int63()), NewWithSeed(func(a, b interface{}) int { return a.(int) - b.(int) }, r.Int63())
+			for _, key := range keysA {
+				a.Put(key, "a")
+			}
+			for _, key := range keysB {
+				b.Put(key, "b")
+			}
+			test.operation(a, b)
+			assertValidTreap(t, a)
+			sort.Ints(test.expected)
+			if actualValue, expectedValue := fmt.Sprint(a.Keys()), fmt.Sprint(test.expected); actualValue != expectedValue {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+			if !b.Empty() {
+				t.Errorf("Got %v expected %v", b.Size(), 0)
+			}
+		}
+	}
+}
+
+// assertValidTreap检查key的顺序、优先级的堆性质以及子树大小
+func assertValidTreap(t *testing.T, tree *Tree) {
+	var check func(n *Node) int
+	check = func(n *Node) int {
+		if n == nil {
+			return 0
+		}
+		for d, child := range n.Children {
+			if child == nil {
+				continue
+			}
+			if child.priority > n.priority {
+				t.Errorf("Got child %v with a higher priority than %v", child, n)
+			}
+			if cmp := tree.Comparator(child.Key, n.Key); (d == 0 && cmp >= 0) || (d == 1 && cmp <= 0) {
+				t.Errorf("Got child %v on the wrong side of %v", child, n)
+			}
+		}
+		size := 1 + check(n.Children[0]) + check(n.Children[1])
+		if size != n.size {
+			t.Errorf("Got size %v expected %v for %v", n.size, size, n)
+		}
+		return size
+	}
+	check(tree.Root)
+	keys := tree.Keys()
+	for i := 1; i < len(keys); i++ {
+		if tree.Comparator(keys[i-1], keys[i]) >= 0 {
+			t.Errorf("Got %v before %v", keys[i-1], keys[i])
+		}
+	}
+}
+
+func TestTreapIterator(t *testing.T) {
+	tree := NewWithIntComparator()
+	for _, key := range []int{4, 2, 5, 1, 3} {
+		tree.Put(key, key)
+	}
+	it := tree.Iterator()
+	keys := []interface{}{}
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	for it.Prev() {
+		keys = append(keys, it.Value())
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[1 2 3 4 5 5 4 3 2 1]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestTreapSortedMap(t *testing.T) {
+	containertest.TestSortedMap(t, func() container.SortedMap { return NewWithIntComparator() })
+}
+
+func TestTreapSerialization(t *testing.T) {
+	tree := NewWithStringComparator()
+	tree.Put("c", 3)
+	tree.Put("a", 1)
+	tree.Put("b", 2)
+
+	json, err := tree.ToJSON()
+	if err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	restored := NewWithStringComparator()
+	if err := restored.FromJSON(json); err != nil {
+		t.Errorf("Got error %v", err)
+	}
+	if actualValue, expectedValue := fmt.Sprint(restored.Keys(), restored.Values()), "[a b c] [1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func benchmarkUnion(b *testing.B, size int, otherSize int) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		tree, other := NewWithIntComparator(), NewWithIntComparator()
+		for n := 0; n < size; n++ {
+			tree.Put(n*2, struct{}{})
+		}
+		for n := 0; n < otherSize; n++ {
+			other.Put(n*size/otherSize*2+1, struct{}{})
+		}
+		b.StartTimer()
+		tree.Union(other)
+	}
+}
+
+func BenchmarkTreapUnion10000With10(b *testing.B) {
+	benchmarkUnion(b, 10000, 10)
+}
+
+func BenchmarkTreapUnion10000With1000(b *testing.B) {
+	benchmarkUnion(b, 10000, 1000)
+}
+
+func BenchmarkTreapUnion10000With10000(b *testing.B) {
+	benchmarkUnion(b, 10000, 10000)
+}
+
+func benchmarkGet(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Get(n)
+		}
+	}
+}
+
+func benchmarkPut(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Put(n, struct{}{})
+		}
+	}
+}
+
+func benchmarkRemove(b *testing.B, tree *Tree, size int) {
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < size; n++ {
+			tree.Remove(n)
+		}
+	}
+}
+
+func BenchmarkTreapGet100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	tree := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, tree, size)
+}
+
+func BenchmarkTreapGet1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, tree, size)
+}
+
+func BenchmarkTreapGet10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	tree := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkGet(b, tree, size)
+}
+
+func BenchmarkTreapPut100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	tree := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPut(b, tree, size)
+}
+
+func BenchmarkTreapPut1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPut(b, tree, size)
+}
+
+func BenchmarkTreapPut10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	tree := NewWithIntComparator()
+	b.StartTimer()
+	benchmarkPut(b, tree, size)
+}
+
+func BenchmarkTreapRemove100(b *testing.B) {
+	b.StopTimer()
+	size := 100
+	tree := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkRemove(b, tree, size)
+}
+
+func BenchmarkTreapRemove1000(b *testing.B) {
+	b.StopTimer()
+	size := 1000
+	tree := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkRemove(b, tree, size)
+}
+
+func BenchmarkTreapRemove10000(b *testing.B) {
+	b.StopTimer()
+	size := 10000
+	tree := NewWithIntComparator()
+	for n := 0; n < size; n++ {
+		tree.Put(n, struct{}{})
+	}
+	b.StartTimer()
+	benchmarkRemove(b, tree, size)
+}
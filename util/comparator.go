@@ -1,5 +1,7 @@
 package util
 
+import "cmp"
+
 type Comparator func(a, b interface{}) int
 
 func StringComparator(a, b interface{}) int {
@@ -37,3 +39,19 @@ func IntComparator(a, b interface{}) int {
 		return 0
 	}
 }
+
+// TypedComparator compares two values of type T, it returns a negative number if a < b,
+// zero if a == b and a positive number if a > b.
+type TypedComparator[T any] func(a, b T) int
+
+// OrderedComparator is the TypedComparator of the natural order of T.
+func OrderedComparator[T cmp.Ordered](a, b T) int {
+	return cmp.Compare(a, b)
+}
+
+// Untyped adapts c to a Comparator whose arguments hold values of type T.
+func (c TypedComparator[T]) Untyped() Comparator {
+	return func(a, b interface{}) int {
+		return c(a.(T), b.(T))
+	}
+}
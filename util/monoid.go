@@ -0,0 +1,59 @@
+package util
+
+import "math"
+
+// Combiner is an associative binary operation: Combine(Combine(a, b), c) == Combine(a, Combine(b, c)).
+type Combiner func(a, b interface{}) interface{}
+
+// Monoid is a Combiner together with its Identity, Combine(Identity, a) == Combine(a, Identity) == a.
+// Inverse is optional, a monoid with an Inverse is a group: Combine(a, Inverse(a)) == Identity.
+type Monoid struct {
+	Combine  Combiner
+	Identity interface{}
+	Inverse  func(a interface{}) interface{}
+}
+
+// Power returns value combined with itself n times, the Identity if n is 0.
+func (m Monoid) Power(value interface{}, n int) interface{} {
+	result := m.Identity
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result = m.Combine(result, value)
+		}
+		value = m.Combine(value, value)
+	}
+	return result
+}
+
+// IntSumMonoid adds ints, it has an Inverse.
+var IntSumMonoid = Monoid{
+	Combine: func(a, b interface{}) interface{} {
+		return a.(int) + b.(int)
+	},
+	Identity: 0,
+	Inverse: func(a interface{}) interface{} {
+		return -a.(int)
+	},
+}
+
+// IntMinMonoid keeps the smaller int.
+var IntMinMonoid = Monoid{
+	Combine: func(a, b interface{}) interface{} {
+		if a.(int) <= b.(int) {
+			return a
+		}
+		return b
+	},
+	Identity: math.MaxInt,
+}
+
+// IntMaxMonoid keeps the larger int.
+var IntMaxMonoid = Monoid{
+	Combine: func(a, b interface{}) interface{} {
+		if a.(int) >= b.(int) {
+			return a
+		}
+		return b
+	},
+	Identity: math.MinInt,
+}
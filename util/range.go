@@ -0,0 +1,31 @@
+package util
+
+// Inclusion describes which ends of a range belong to the range.
+type Inclusion byte
+
+const (
+	// IncludeNone is the open range (from, to).
+	IncludeNone Inclusion = 0
+	// IncludeFrom is the half-open range [from, to).
+	IncludeFrom Inclusion = 1 << 0
+	// IncludeTo is the half-open range (from, to].
+	IncludeTo Inclusion = 1 << 1
+	// IncludeBoth is the closed range [from, to].
+	IncludeBoth = IncludeFrom | IncludeTo
+	// UnboundedFrom leaves the from end of the range unbounded, from is ignored.
+	// It is how the type-parameterized trees, whose keys can't be nil, express a nil from.
+	UnboundedFrom Inclusion = 1 << 2
+	// UnboundedTo leaves the to end of the range unbounded, to is ignored.
+	UnboundedTo Inclusion = 1 << 3
+)
+
+// Bounds returns from and to with the unbounded ends replaced by nil.
+func (inclusion Inclusion) Bounds(from interface{}, to interface{}) (interface{}, interface{}) {
+	if inclusion&UnboundedFrom != 0 {
+		from = nil
+	}
+	if inclusion&UnboundedTo != 0 {
+		to = nil
+	}
+	return from, to
+}
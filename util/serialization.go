@@ -0,0 +1,188 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// KeyDecoder decodes a JSON encoded key back into the type expected by the comparator.
+type KeyDecoder func(data []byte) (interface{}, error)
+
+// ErrNoKeyDecoder is returned when a key of a custom type is decoded without a KeyDecoder.
+var ErrNoKeyDecoder = errors.New("key of custom type requires a key decoder")
+
+// ValueDecoder decodes a JSON encoded value back into the type expected by the comparator.
+type ValueDecoder func(data []byte) (interface{}, error)
+
+// ErrNotConstructed is returned when JSON is decoded into a zero value container, which has no comparator.
+var ErrNotConstructed = errors.New("container must be created by a constructor before decoding into it")
+
+// jsonEntry is the JSON representation of a key value pair of an ordered map.
+// Type records the Go type of built-in keys so that they can be restored without a KeyDecoder.
+type jsonEntry struct {
+	Type  string          `json:"type,omitempty"`
+	Key   json.RawMessage `json:"key"`
+	Value interface{}     `json:"value"`
+}
+
+// MarshalEntries encodes the key value pairs as a JSON array of {type,key,value} objects, preserving their order.
+func MarshalEntries(keys []interface{}, values []interface{}) ([]byte, error) {
+	entries := make([]jsonEntry, len(keys))
+	for i, key := range keys {
+		data, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = jsonEntry{Type: keyType(key), Key: data, Value: values[i]}
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalEntries decodes the output of MarshalEntries.
+// Keys are restored by decoder if it is not nil, otherwise by their recorded type.
+func UnmarshalEntries(data []byte, decoder KeyDecoder) (keys []interface{}, values []interface{}, err error) {
+	var entries []jsonEntry
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return nil, nil, err
+	}
+	keys = make([]interface{}, len(entries))
+	values = make([]interface{}, len(entries))
+	for i, entry := range entries {
+		if keys[i], err = entry.decodeKey(decoder); err != nil {
+			return nil, nil, err
+		}
+		values[i] = entry.Value
+	}
+	return keys, values, nil
+}
+
+// UnmarshalValues decodes a JSON array whose values are restored by decoder if it is not nil.
+// Otherwise strings and bools keep their type, integers become int and other numbers float64.
+func UnmarshalValues(data []byte, decoder ValueDecoder) ([]interface{}, error) {
+	var elements []json.RawMessage
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return nil, err
+	}
+	if decoder == nil {
+		decoder = decodeValue
+	}
+	values := make([]interface{}, len(elements))
+	for i, element := range elements {
+		value, err := decoder(element)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func decodeValue(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if number, ok := value.(json.Number); ok {
+		if i, err := strconv.ParseInt(string(number), 10, 0); err == nil {
+			return int(i), nil
+		}
+		return number.Float64()
+	}
+	return value, nil
+}
+
+func (entry jsonEntry) decodeKey(decoder KeyDecoder) (interface{}, error) {
+	if decoder != nil {
+		return decoder(entry.Key)
+	}
+	switch entry.Type {
+	case "string":
+		var s string
+		err := json.Unmarshal(entry.Key, &s)
+		return s, err
+	case "bool":
+		var b bool
+		err := json.Unmarshal(entry.Key, &b)
+		return b, err
+	}
+	return decodeNumber(entry.Type, string(entry.Key))
+}
+
+func decodeNumber(typ string, s string) (interface{}, error) {
+	switch typ {
+	case "int":
+		i, err := strconv.ParseInt(s, 10, 0)
+		return int(i), err
+	case "int8":
+		i, err := strconv.ParseInt(s, 10, 8)
+		return int8(i), err
+	case "int16":
+		i, err := strconv.ParseInt(s, 10, 16)
+		return int16(i), err
+	case "int32":
+		i, err := strconv.ParseInt(s, 10, 32)
+		return int32(i), err
+	case "int64":
+		return strconv.ParseInt(s, 10, 64)
+	case "uint":
+		u, err := strconv.ParseUint(s, 10, 0)
+		return uint(u), err
+	case "uint8":
+		u, err := strconv.ParseUint(s, 10, 8)
+		return uint8(u), err
+	case "uint16":
+		u, err := strconv.ParseUint(s, 10, 16)
+		return uint16(u), err
+	case "uint32":
+		u, err := strconv.ParseUint(s, 10, 32)
+		return uint32(u), err
+	case "uint64":
+		return strconv.ParseUint(s, 10, 64)
+	case "float32":
+		f, err := strconv.ParseFloat(s, 32)
+		return float32(f), err
+	case "float64":
+		return strconv.ParseFloat(s, 64)
+	default:
+		return nil, ErrNoKeyDecoder
+	}
+}
+
+func keyType(key interface{}) string {
+	switch key.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case int:
+		return "int"
+	case int8:
+		return "int8"
+	case int16:
+		return "int16"
+	case int32:
+		return "int32"
+	case int64:
+		return "int64"
+	case uint:
+		return "uint"
+	case uint8:
+		return "uint8"
+	case uint16:
+		return "uint16"
+	case uint32:
+		return "uint32"
+	case uint64:
+		return "uint64"
+	case float32:
+		return "float32"
+	case float64:
+		return "float64"
+	default:
+		return ""
+	}
+}